@@ -0,0 +1,5 @@
+data "onespansign_account" "current" {}
+
+output "account_name" {
+  value = data.onespansign_account.current.name
+}
@@ -0,0 +1 @@
+data "onespansign_account_signing_logos" "example" {}
@@ -0,0 +1,28 @@
+data "onespansign_ceremony_preview" "example" {
+  theme {
+    name                      = "brand"
+    primary                   = "#1A4F9C"
+    success                   = "#2E7D32"
+    warning                   = "#F2C200"
+    error                     = "#C62828"
+    info                      = "#1565C0"
+    signature_button          = "#1A4F9C"
+    optional_signature_button = "#5C6BC0"
+  }
+
+  logo {
+    language = "en"
+    source   = "${path.module}/logos/en.png"
+  }
+
+  logo {
+    language = "fr"
+    source   = "${path.module}/logos/fr.png"
+  }
+}
+
+# The preview can be attached to the review of the theme or logo change
+resource "local_file" "preview" {
+  filename = "${path.module}/ceremony-preview.html"
+  content  = data.onespansign_ceremony_preview.example.html
+}
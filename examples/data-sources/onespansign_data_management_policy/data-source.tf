@@ -0,0 +1 @@
+data "onespansign_data_management_policy" "example" {}
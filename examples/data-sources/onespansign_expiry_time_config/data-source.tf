@@ -0,0 +1 @@
+data "onespansign_expiry_time_config" "example" {}
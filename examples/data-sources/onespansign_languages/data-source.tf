@@ -0,0 +1,5 @@
+data "onespansign_languages" "current" {}
+
+output "languages" {
+  value = data.onespansign_languages.current.languages
+}
@@ -0,0 +1,19 @@
+data "onespansign_theme_palette" "brand" {
+  primary  = "#1A4F9C"
+  strategy = "complementary"
+}
+
+resource "onespansign_account_signing_themes" "example" {
+  accessibility_level = "AA"
+
+  theme {
+    name                      = "default"
+    primary                   = data.onespansign_theme_palette.brand.primary
+    success                   = data.onespansign_theme_palette.brand.success
+    warning                   = data.onespansign_theme_palette.brand.warning
+    error                     = data.onespansign_theme_palette.brand.error
+    info                      = data.onespansign_theme_palette.brand.info
+    signature_button          = data.onespansign_theme_palette.brand.signature_button
+    optional_signature_button = data.onespansign_theme_palette.brand.optional_signature_button
+  }
+}
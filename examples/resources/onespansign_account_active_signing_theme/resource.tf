@@ -0,0 +1,3 @@
+resource "onespansign_account_active_signing_theme" "example" {
+  name = onespansign_account_signing_theme.winter.name
+}
@@ -0,0 +1,2 @@
+# Signing logos are imported with their language, optionally prefixed by the ID of the OneSpan Sign account or the "account" alias
+terraform import onespansign_account_signing_logo.example account/fr
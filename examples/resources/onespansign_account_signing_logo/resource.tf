@@ -0,0 +1,4 @@
+resource "onespansign_account_signing_logo" "example" {
+  language = "fr"
+  source   = "${path.module}/logo-fr.png"
+}
@@ -0,0 +1,2 @@
+# Singleton resources are imported with the ID of the OneSpan Sign account, or with the "account" alias
+terraform import onespansign_account_signing_logos.example account
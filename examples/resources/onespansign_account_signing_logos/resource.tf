@@ -1,4 +1,6 @@
 resource "onespansign_account_signing_logos" "example" {
+  default_image = "data:image/png;base64,<BASE64_IMAGE_DATA>"
+
   logo {
     language = "en"
     image    = "data:image/png;base64,<BASE64_IMAGE_DATA>"
@@ -6,6 +8,6 @@ resource "onespansign_account_signing_logos" "example" {
 
   logo {
     language = "fr"
-    image    = "data:image/png;base64,<BASE64_IMAGE_DATA>"
+    source   = "${path.module}/logo-fr.png"
   }
 }
\ No newline at end of file
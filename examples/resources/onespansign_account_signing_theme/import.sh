@@ -0,0 +1,2 @@
+# Signing themes are imported with their name, optionally prefixed by the ID of the OneSpan Sign account or the "account" alias
+terraform import onespansign_account_signing_theme.winter account/winter
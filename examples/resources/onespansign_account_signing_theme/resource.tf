@@ -0,0 +1,12 @@
+resource "onespansign_account_signing_theme" "winter" {
+  name                      = "winter"
+  primary                   = "#1A4F9C"
+  success                   = "#1E7A34"
+  warning                   = "#F2C200"
+  error                     = "#B3261E"
+  info                      = "#0B6BA8"
+  signature_button          = "#1A4F9C"
+  optional_signature_button = "#595959"
+
+  accessibility_level = "AA"
+}
@@ -0,0 +1,2 @@
+# Senders are imported with their ID
+terraform import onespansign_sender.underwriter 3f5c2a1e-8b7d-4c6a-9e0f-1a2b3c4d5e6f
@@ -0,0 +1,19 @@
+# Invited by email, the sender becomes active when they accept the invitation
+resource "onespansign_sender" "underwriter" {
+  email      = "jane.doe@example.com"
+  first_name = "Jane"
+  last_name  = "Doe"
+  company    = "Example Inc."
+  title      = "Underwriter"
+  language   = "en"
+}
+
+# Created without an invitation, and only deactivated on destroy to keep the transactions they own
+resource "onespansign_sender" "manager" {
+  email      = "john.doe@example.com"
+  first_name = "John"
+  last_name  = "Doe"
+  type       = "MANAGER"
+  status     = "ACTIVE"
+  on_destroy = "deactivate"
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	accessibilityLevelOff = "off"
+	accessibilityLevelAA  = "AA"
+	accessibilityLevelAAA = "AAA"
+
+	accessibilitySeverityError   = "error"
+	accessibilitySeverityWarning = "warning"
+
+	// minColorDifference is the CIE76 color difference below which two colors are considered indistinguishable.
+	minColorDifference = 10
+)
+
+// minContrastRatios are the minimum contrast ratios of normal text required by the WCAG 2.1 levels.
+//
+// https://www.w3.org/TR/WCAG21/#contrast-minimum
+var minContrastRatios = map[string]float64{
+	accessibilityLevelAA:  4.5,
+	accessibilityLevelAAA: 7,
+}
+
+// themeColorTexts maps the theme colors to the color of the ceremony's text displayed over them. The buttons and
+// notifications have white labels, except the warning notifications which have black labels.
+var themeColorTexts = map[string]string{
+	"primary":                   "#FFFFFF",
+	"success":                   "#FFFFFF",
+	"warning":                   "#000000",
+	"error":                     "#FFFFFF",
+	"info":                      "#FFFFFF",
+	"signature_button":          "#FFFFFF",
+	"optional_signature_button": "#FFFFFF",
+}
+
+// themeColorPairs are the theme colors that signers must be able to tell apart.
+var themeColorPairs = [][2]string{
+	{"success", "warning"},
+	{"success", "error"},
+	{"success", "info"},
+	{"warning", "error"},
+	{"warning", "info"},
+	{"error", "info"},
+	{"signature_button", "optional_signature_button"},
+}
+
+// colorVisionDeficiencies are the matrices simulating the common color vision deficiencies on linear RGB colors.
+//
+// https://www.inf.ufrgs.br/~oliveira/pubs_files/CVD_Simulation/CVD_Simulation.html
+var colorVisionDeficiencies = map[string][3][3]float64{
+	"protanopia": {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	"deuteranopia": {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	"tritanopia": {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// linearRgb returns the linear RGB components of the color c, in [0, 1].
+func linearRgb(c string) ([3]float64, error) {
+	var r [3]float64
+
+	h, err := normalizeColor(c)
+	if err != nil {
+		return r, err
+	}
+
+	for i := range r {
+		v, err := strconv.ParseUint(h[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return r, err
+		}
+
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			r[i] = s / 12.92
+		} else {
+			r[i] = math.Pow((s+0.055)/1.055, 2.4)
+		}
+	}
+
+	return r, nil
+}
+
+// relativeLuminance returns the WCAG relative luminance of the linear RGB color l.
+//
+// https://www.w3.org/TR/WCAG21/#dfn-relative-luminance
+func relativeLuminance(l [3]float64) float64 {
+	return 0.2126*l[0] + 0.7152*l[1] + 0.0722*l[2]
+}
+
+// contrastRatio returns the WCAG contrast ratio between the colors a and b, from 1 to 21.
+//
+// https://www.w3.org/TR/WCAG21/#dfn-contrast-ratio
+func contrastRatio(a string, b string) (float64, error) {
+	la, err := linearRgb(a)
+	if err != nil {
+		return 0, err
+	}
+
+	lb, err := linearRgb(b)
+	if err != nil {
+		return 0, err
+	}
+
+	l1, l2 := relativeLuminance(la), relativeLuminance(lb)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+
+	return (l1 + 0.05) / (l2 + 0.05), nil
+}
+
+// lab converts the linear RGB color l to the CIELAB color space, with the D65 white point.
+func lab(l [3]float64) [3]float64 {
+	x := (0.4124*l[0] + 0.3576*l[1] + 0.1805*l[2]) / 0.95047
+	y := 0.2126*l[0] + 0.7152*l[1] + 0.0722*l[2]
+	z := (0.0193*l[0] + 0.1192*l[1] + 0.9505*l[2]) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+
+	fx, fy, fz := f(x), f(y), f(z)
+
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+// colorDifference returns the CIE76 color difference between the linear RGB colors a and b.
+func colorDifference(a [3]float64, b [3]float64) float64 {
+	la, lb := lab(a), lab(b)
+
+	return math.Sqrt(math.Pow(la[0]-lb[0], 2) + math.Pow(la[1]-lb[1], 2) + math.Pow(la[2]-lb[2], 2))
+}
+
+// simulateColorVision returns the linear RGB color l as seen with the color vision deficiency m.
+func simulateColorVision(m [3][3]float64, l [3]float64) [3]float64 {
+	var r [3]float64
+
+	for i := range r {
+		r[i] = math.Min(1, math.Max(0, m[i][0]*l[0]+m[i][1]*l[1]+m[i][2]*l[2]))
+	}
+
+	return r
+}
+
+// checkThemeContrast checks that the theme colors t, keyed by attribute name, have enough contrast with the
+// ceremony's text to meet the WCAG 2.1 level. The colors that are not valid or not known yet are skipped.
+func checkThemeContrast(t map[string]string, level string) []string {
+	minRatio, ok := minContrastRatios[level]
+	if !ok {
+		return nil
+	}
+
+	var r []string
+
+	for k, c := range t {
+		txt, ok := themeColorTexts[k]
+		if !ok {
+			continue
+		}
+
+		cr, err := contrastRatio(c, txt)
+		if err != nil {
+			continue
+		}
+
+		if cr >= minRatio {
+			continue
+		}
+
+		// The contrast with the other text is reported for reference, e.g. to pick a darker or lighter color
+		o := "#000000"
+		if txt == o {
+			o = "#FFFFFF"
+		}
+
+		ocr, _ := contrastRatio(c, o)
+
+		r = append(r, fmt.Sprintf("%s: the contrast ratio of %s with the %s text is %.2f:1 (%.2f:1 with %s text), WCAG %s requires at least %.1f:1",
+			k, c, txt, cr, ocr, o, level, minRatio))
+	}
+
+	sort.Strings(r)
+
+	return r
+}
+
+// checkThemeColorVision checks that the theme colors t, keyed by attribute name, that signers must tell apart
+// remain distinguishable with the common color vision deficiencies. The pairs of colors that are already close
+// with a normal vision are deliberate and are not reported.
+func checkThemeColorVision(t map[string]string) []string {
+	var r []string
+
+	for _, p := range themeColorPairs {
+		a, err := linearRgb(t[p[0]])
+		if err != nil {
+			continue
+		}
+
+		b, err := linearRgb(t[p[1]])
+		if err != nil {
+			continue
+		}
+
+		if colorDifference(a, b) < minColorDifference {
+			continue
+		}
+
+		for n, m := range colorVisionDeficiencies {
+			if colorDifference(simulateColorVision(m, a), simulateColorVision(m, b)) < minColorDifference {
+				r = append(r, fmt.Sprintf("%s and %s: %s and %s are hard to tell apart with %s", p[0], p[1], t[p[0]], t[p[1]], n))
+			}
+		}
+	}
+
+	sort.Strings(r)
+
+	return r
+}
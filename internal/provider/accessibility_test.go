@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrastRatio(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		e    float64
+	}{
+		{"#FFFFFF", "#000000", 21},
+		{"#000000", "#FFFFFF", 21},
+		{"#FFFFFF", "#FFFFFF", 1},
+		{"#767676", "#FFFFFF", 4.54},
+		{"#595959", "#FFFFFF", 7},
+		{"lightyellow", "#FFFFFF", 1.02},
+	} {
+		r, err := contrastRatio(c.a, c.b)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, c.e, r, 0.01, "%s/%s", c.a, c.b)
+	}
+
+	_, err := contrastRatio("notacolor", "#FFFFFF")
+	assert.Error(t, err)
+}
+
+func TestCheckThemeContrast(t *testing.T) {
+	th := map[string]string{
+		"primary":                   "#1A4F9C",
+		"success":                   "#1E7A34",
+		"warning":                   "#F2C200",
+		"error":                     "#B3261E",
+		"info":                      "#0B6BA8",
+		"signature_button":          "#595959",
+		"optional_signature_button": "#767676",
+	}
+
+	assert.Empty(t, checkThemeContrast(th, accessibilityLevelOff))
+	assert.Empty(t, checkThemeContrast(th, ""))
+	assert.Empty(t, checkThemeContrast(th, accessibilityLevelAA))
+
+	v := checkThemeContrast(th, accessibilityLevelAAA)
+	if assert.Len(t, v, 4) {
+		assert.Contains(t, v[0], "error")
+		assert.Contains(t, v[1], "info")
+		assert.Contains(t, v[2], "optional_signature_button: the contrast ratio of #767676 with the #FFFFFF text is 4.54:1 (4.62:1 with #000000 text), WCAG AAA requires at least 7.0:1")
+		assert.Contains(t, v[3], "success")
+	}
+
+	th["signature_button"] = "lightyellow"
+	th["warning"] = "#000080"
+	th["info"] = "${unknown}"
+
+	v = checkThemeContrast(th, accessibilityLevelAA)
+	if assert.Len(t, v, 2) {
+		assert.Contains(t, v[0], "signature_button: the contrast ratio of lightyellow with the #FFFFFF text is 1.02:1 (20.63:1 with #000000 text)")
+		assert.Contains(t, v[1], "warning")
+	}
+}
+
+func TestCheckThemeColorVision(t *testing.T) {
+	th := map[string]string{
+		"success":                   "#2E7D32",
+		"warning":                   "#F9A825",
+		"error":                     "#C62828",
+		"info":                      "#1565C0",
+		"signature_button":          "#1565C0",
+		"optional_signature_button": "#90CAF9",
+	}
+
+	assert.Empty(t, checkThemeColorVision(th))
+
+	// Green and red of a similar lightness are confused with deuteranopia
+	th["success"] = "#00A000"
+	th["error"] = "#D04000"
+
+	assert.Equal(t, []string{"success and error: #00A000 and #D04000 are hard to tell apart with deuteranopia"}, checkThemeColorVision(th))
+
+	// Colors that are already close are deliberate
+	th["success"] = "#D04001"
+	assert.Empty(t, checkThemeColorVision(th))
+}
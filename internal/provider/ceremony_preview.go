@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+)
+
+// ceremonyPreview is the content of the static mock of the Signing Ceremony.
+type ceremonyPreview struct {
+	// Theme is the name of the previewed theme.
+	Theme string
+
+	// Colors are the colors of the theme, keyed by attribute name.
+	Colors map[string]string
+
+	// Logos are the data URIs of the logos, keyed by language.
+	Logos map[string]string
+
+	// Languages are the languages of the ceremony to render, in order.
+	Languages []string
+}
+
+// ceremonyPreviewColor is a theme color in the contrast table of the preview.
+type ceremonyPreviewColor struct {
+	Name     string
+	Color    string
+	Text     string
+	Contrast string
+	AA       bool
+	AAA      bool
+}
+
+// ceremonyPreviewPage is the mock of the ceremony in a language.
+type ceremonyPreviewPage struct {
+	Language string
+	Logo     template.URL
+}
+
+var ceremonyPreviewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Signing Ceremony preview - {{.Theme}}</title>
+<style>
+  body { margin: 0; padding: 24px; background: #F5F5F5; font-family: Arial, Helvetica, sans-serif; color: #333333; }
+  h1 { font-size: 20px; }
+  section.page { margin: 24px 0; background: #FFFFFF; border: 1px solid #DDDDDD; }
+  header { display: flex; align-items: center; justify-content: space-between; padding: 12px 24px; border-bottom: 4px solid {{index .Colors "primary"}}; }
+  header img { max-height: 60px; max-width: 300px; }
+  header .language { color: {{index .Colors "primary"}}; font-weight: bold; }
+  .content { padding: 24px; }
+  .notification { padding: 12px 16px; margin-bottom: 8px; border-radius: 4px; }
+  .document { margin: 24px 0; padding: 24px; border: 1px solid #DDDDDD; min-height: 120px; }
+  .document p { margin: 0 0 8px; }
+  .actions { display: flex; gap: 12px; }
+  .button { padding: 10px 20px; border-radius: 4px; font-weight: bold; }
+  .primary { background: {{index .Colors "primary"}}; color: #FFFFFF; }
+  table { border-collapse: collapse; background: #FFFFFF; }
+  td, th { border: 1px solid #DDDDDD; padding: 6px 12px; text-align: left; }
+  .swatch { display: inline-block; width: 16px; height: 16px; vertical-align: middle; border: 1px solid #999999; }
+</style>
+</head>
+<body>
+<h1>Signing Ceremony preview of the &quot;{{.Theme}}&quot; theme</h1>
+<p>This is a static mock of the Signing Ceremony, the actual layout may differ.</p>
+<table>
+  <tr><th>Color</th><th>Value</th><th>Text</th><th>Contrast</th><th>WCAG AA</th><th>WCAG AAA</th></tr>
+  {{- range .Table}}
+  <tr>
+    <td>{{.Name}}</td>
+    <td><span class="swatch" style="background: {{.Color}}"></span> {{.Color}}</td>
+    <td>{{.Text}}</td>
+    <td>{{.Contrast}}</td>
+    <td>{{if .AA}}pass{{else}}fail{{end}}</td>
+    <td>{{if .AAA}}pass{{else}}fail{{end}}</td>
+  </tr>
+  {{- end}}
+</table>
+{{- range .Pages}}
+<section class="page">
+  <header>
+    {{if .Logo}}<img src="{{.Logo}}" alt="Logo ({{.Language}})">{{else}}<span>No logo for this language</span>{{end}}
+    <span class="language">{{.Language}}</span>
+  </header>
+  <div class="content">
+    <div class="notification" style="background: {{index $.Colors "success"}}; color: {{index $.Text "success"}}">Success: the document was signed.</div>
+    <div class="notification" style="background: {{index $.Colors "warning"}}; color: {{index $.Text "warning"}}">Warning: some fields are incomplete.</div>
+    <div class="notification" style="background: {{index $.Colors "error"}}; color: {{index $.Text "error"}}">Error: the signature could not be applied.</div>
+    <div class="notification" style="background: {{index $.Colors "info"}}; color: {{index $.Text "info"}}">Info: review the document before signing.</div>
+    <div class="document">
+      <p>Document to sign</p>
+      <p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>
+    </div>
+    <div class="actions">
+      <span class="button" style="background: {{index $.Colors "signature_button"}}; color: {{index $.Text "signature_button"}}">Click to sign</span>
+      <span class="button" style="background: {{index $.Colors "optional_signature_button"}}; color: {{index $.Text "optional_signature_button"}}">Click to sign (optional)</span>
+      <span class="button primary">Confirm</span>
+    </div>
+  </div>
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// render renders the preview as a standalone HTML document. The colors must be valid and the logos must be
+// checked images, they are embedded as-is.
+func (p ceremonyPreview) render() (string, error) {
+	colors := make(map[string]string, len(p.Colors))
+	for k, v := range p.Colors {
+		c, err := normalizeColor(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s color: %w", k, err)
+		}
+		colors[k] = c
+	}
+
+	ks := make([]string, 0, len(themeColorTexts))
+	for k := range themeColorTexts {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+
+	table := make([]ceremonyPreviewColor, 0, len(ks))
+	for _, k := range ks {
+		cr, err := contrastRatio(colors[k], themeColorTexts[k])
+		if err != nil {
+			return "", fmt.Errorf("invalid %s color: %w", k, err)
+		}
+
+		table = append(table, ceremonyPreviewColor{
+			Name:     k,
+			Color:    colors[k],
+			Text:     themeColorTexts[k],
+			Contrast: fmt.Sprintf("%.2f:1", cr),
+			AA:       cr >= minContrastRatios[accessibilityLevelAA],
+			AAA:      cr >= minContrastRatios[accessibilityLevelAAA],
+		})
+	}
+
+	pages := make([]ceremonyPreviewPage, len(p.Languages))
+	for i, l := range p.Languages {
+		// The images are checked when they are configured, including the SVG images
+		pages[i] = ceremonyPreviewPage{
+			Language: l,
+			Logo:     template.URL(p.Logos[l]),
+		}
+	}
+
+	var b bytes.Buffer
+
+	err := ceremonyPreviewTemplate.Execute(&b, map[string]interface{}{
+		"Theme":  p.Theme,
+		"Colors": colors,
+		"Text":   themeColorTexts,
+		"Table":  table,
+		"Pages":  pages,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCeremonyPreview(t *testing.T) {
+	p := ceremonyPreview{
+		Theme: `<script>alert("theme")</script>`,
+		Colors: map[string]string{
+			"primary":                   "navy",
+			"success":                   "#2E7D32",
+			"warning":                   "#F2C200",
+			"error":                     "#C62828",
+			"info":                      "#1565C0",
+			"signature_button":          "#1a4f9c",
+			"optional_signature_button": "#FFEB3B",
+		},
+		Logos: map[string]string{
+			"en": testImg,
+		},
+		Languages: []string{"en", "fr"},
+	}
+
+	h, err := p.render()
+	assert.NoError(t, err)
+
+	// Colors are rendered in their canonical form
+	assert.Contains(t, h, "background: #000080")
+	assert.Contains(t, h, "#1A4F9C")
+	assert.NotContains(t, h, "#1a4f9c")
+
+	// Logos are rendered for their language only
+	assert.Equal(t, 1, strings.Count(h, `<img src="data:image/png;base64,`))
+	assert.Contains(t, h, "No logo for this language")
+
+	// The contrast table reports the colors that don't meet the WCAG levels
+	assert.Contains(t, h, "<td>optional_signature_button</td>")
+	assert.Contains(t, h, "1.22:1")
+	assert.Contains(t, h, "<td>fail</td>")
+
+	// Names are escaped
+	assert.NotContains(t, h, `<script>alert("theme")</script>`)
+	assert.Contains(t, h, "&lt;script&gt;")
+
+	p.Colors["error"] = "notacolor"
+	_, err = p.render()
+	assert.Error(t, err)
+}
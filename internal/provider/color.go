@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// colorHexPattern matches the 3-digit and 6-digit color hex codes, in any case.
+var colorHexPattern = regexp.MustCompile("^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$")
+
+// cssNamedColors maps the CSS named colors to their color hex code.
+//
+// https://www.w3.org/TR/css-color-4/#named-colors
+var cssNamedColors = map[string]string{
+	"aliceblue":            "#F0F8FF",
+	"antiquewhite":         "#FAEBD7",
+	"aqua":                 "#00FFFF",
+	"aquamarine":           "#7FFFD4",
+	"azure":                "#F0FFFF",
+	"beige":                "#F5F5DC",
+	"bisque":               "#FFE4C4",
+	"black":                "#000000",
+	"blanchedalmond":       "#FFEBCD",
+	"blue":                 "#0000FF",
+	"blueviolet":           "#8A2BE2",
+	"brown":                "#A52A2A",
+	"burlywood":            "#DEB887",
+	"cadetblue":            "#5F9EA0",
+	"chartreuse":           "#7FFF00",
+	"chocolate":            "#D2691E",
+	"coral":                "#FF7F50",
+	"cornflowerblue":       "#6495ED",
+	"cornsilk":             "#FFF8DC",
+	"crimson":              "#DC143C",
+	"cyan":                 "#00FFFF",
+	"darkblue":             "#00008B",
+	"darkcyan":             "#008B8B",
+	"darkgoldenrod":        "#B8860B",
+	"darkgray":             "#A9A9A9",
+	"darkgreen":            "#006400",
+	"darkgrey":             "#A9A9A9",
+	"darkkhaki":            "#BDB76B",
+	"darkmagenta":          "#8B008B",
+	"darkolivegreen":       "#556B2F",
+	"darkorange":           "#FF8C00",
+	"darkorchid":           "#9932CC",
+	"darkred":              "#8B0000",
+	"darksalmon":           "#E9967A",
+	"darkseagreen":         "#8FBC8F",
+	"darkslateblue":        "#483D8B",
+	"darkslategray":        "#2F4F4F",
+	"darkslategrey":        "#2F4F4F",
+	"darkturquoise":        "#00CED1",
+	"darkviolet":           "#9400D3",
+	"deeppink":             "#FF1493",
+	"deepskyblue":          "#00BFFF",
+	"dimgray":              "#696969",
+	"dimgrey":              "#696969",
+	"dodgerblue":           "#1E90FF",
+	"firebrick":            "#B22222",
+	"floralwhite":          "#FFFAF0",
+	"forestgreen":          "#228B22",
+	"fuchsia":              "#FF00FF",
+	"gainsboro":            "#DCDCDC",
+	"ghostwhite":           "#F8F8FF",
+	"gold":                 "#FFD700",
+	"goldenrod":            "#DAA520",
+	"gray":                 "#808080",
+	"green":                "#008000",
+	"greenyellow":          "#ADFF2F",
+	"grey":                 "#808080",
+	"honeydew":             "#F0FFF0",
+	"hotpink":              "#FF69B4",
+	"indianred":            "#CD5C5C",
+	"indigo":               "#4B0082",
+	"ivory":                "#FFFFF0",
+	"khaki":                "#F0E68C",
+	"lavender":             "#E6E6FA",
+	"lavenderblush":        "#FFF0F5",
+	"lawngreen":            "#7CFC00",
+	"lemonchiffon":         "#FFFACD",
+	"lightblue":            "#ADD8E6",
+	"lightcoral":           "#F08080",
+	"lightcyan":            "#E0FFFF",
+	"lightgoldenrodyellow": "#FAFAD2",
+	"lightgray":            "#D3D3D3",
+	"lightgreen":           "#90EE90",
+	"lightgrey":            "#D3D3D3",
+	"lightpink":            "#FFB6C1",
+	"lightsalmon":          "#FFA07A",
+	"lightseagreen":        "#20B2AA",
+	"lightskyblue":         "#87CEFA",
+	"lightslategray":       "#778899",
+	"lightslategrey":       "#778899",
+	"lightsteelblue":       "#B0C4DE",
+	"lightyellow":          "#FFFFE0",
+	"lime":                 "#00FF00",
+	"limegreen":            "#32CD32",
+	"linen":                "#FAF0E6",
+	"magenta":              "#FF00FF",
+	"maroon":               "#800000",
+	"mediumaquamarine":     "#66CDAA",
+	"mediumblue":           "#0000CD",
+	"mediumorchid":         "#BA55D3",
+	"mediumpurple":         "#9370DB",
+	"mediumseagreen":       "#3CB371",
+	"mediumslateblue":      "#7B68EE",
+	"mediumspringgreen":    "#00FA9A",
+	"mediumturquoise":      "#48D1CC",
+	"mediumvioletred":      "#C71585",
+	"midnightblue":         "#191970",
+	"mintcream":            "#F5FFFA",
+	"mistyrose":            "#FFE4E1",
+	"moccasin":             "#FFE4B5",
+	"navajowhite":          "#FFDEAD",
+	"navy":                 "#000080",
+	"oldlace":              "#FDF5E6",
+	"olive":                "#808000",
+	"olivedrab":            "#6B8E23",
+	"orange":               "#FFA500",
+	"orangered":            "#FF4500",
+	"orchid":               "#DA70D6",
+	"palegoldenrod":        "#EEE8AA",
+	"palegreen":            "#98FB98",
+	"paleturquoise":        "#AFEEEE",
+	"palevioletred":        "#DB7093",
+	"papayawhip":           "#FFEFD5",
+	"peachpuff":            "#FFDAB9",
+	"peru":                 "#CD853F",
+	"pink":                 "#FFC0CB",
+	"plum":                 "#DDA0DD",
+	"powderblue":           "#B0E0E6",
+	"purple":               "#800080",
+	"rebeccapurple":        "#663399",
+	"red":                  "#FF0000",
+	"rosybrown":            "#BC8F8F",
+	"royalblue":            "#4169E1",
+	"saddlebrown":          "#8B4513",
+	"salmon":               "#FA8072",
+	"sandybrown":           "#F4A460",
+	"seagreen":             "#2E8B57",
+	"seashell":             "#FFF5EE",
+	"sienna":               "#A0522D",
+	"silver":               "#C0C0C0",
+	"skyblue":              "#87CEEB",
+	"slateblue":            "#6A5ACD",
+	"slategray":            "#708090",
+	"slategrey":            "#708090",
+	"snow":                 "#FFFAFA",
+	"springgreen":          "#00FF7F",
+	"steelblue":            "#4682B4",
+	"tan":                  "#D2B48C",
+	"teal":                 "#008080",
+	"thistle":              "#D8BFD8",
+	"tomato":               "#FF6347",
+	"turquoise":            "#40E0D0",
+	"violet":               "#EE82EE",
+	"wheat":                "#F5DEB3",
+	"white":                "#FFFFFF",
+	"whitesmoke":           "#F5F5F5",
+	"yellow":               "#FFFF00",
+	"yellowgreen":          "#9ACD32",
+}
+
+// normalizeColor returns the canonical form of the color c, an uppercase 6-digit color hex code (e.g. #FFFFFF).
+// c can be a 3-digit or 6-digit color hex code in any case, or a CSS named color.
+func normalizeColor(c string) (string, error) {
+	v := strings.TrimSpace(c)
+
+	if h, ok := cssNamedColors[strings.ToLower(v)]; ok {
+		return h, nil
+	}
+
+	if !colorHexPattern.MatchString(v) {
+		return "", fmt.Errorf("%q is not a color hex code (e.g. #FFFFFF or #FFF) or a CSS named color (e.g. navy)", c)
+	}
+
+	v = strings.ToUpper(v)
+
+	if len(v) == 4 {
+		v = string([]byte{'#', v[1], v[1], v[2], v[2], v[3], v[3]})
+	}
+
+	return v, nil
+}
+
+// normalizeColorOrKeep returns the canonical form of the color c, or c itself if it's not a valid color.
+func normalizeColorOrKeep(c string) string {
+	if n, err := normalizeColor(c); err == nil {
+		return n
+	}
+
+	return c
+}
+
+func validateColor(v interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := normalizeColor(v.(string)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "invalid color",
+			Detail:        err.Error(),
+			AttributePath: p,
+		})
+	}
+
+	return diags
+}
+
+// suppressEquivalentColorDiff suppresses the differences between two notations of the same color.
+func suppressEquivalentColorDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, err := normalizeColor(old)
+	if err != nil {
+		return false
+	}
+
+	n, err := normalizeColor(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
+
+// hsl is a color in the HSL color space. The hue is in degrees, the saturation and lightness are in [0, 1].
+type hsl struct {
+	h, s, l float64
+}
+
+// parseHsl converts the color c to the HSL color space.
+func parseHsl(c string) (hsl, error) {
+	h, err := normalizeColor(c)
+	if err != nil {
+		return hsl{}, err
+	}
+
+	var rgb [3]float64
+	for i := range rgb {
+		v, err := strconv.ParseUint(h[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return hsl{}, err
+		}
+		rgb[i] = float64(v) / 255
+	}
+
+	r, g, b := rgb[0], rgb[1], rgb[2]
+	mx := math.Max(r, math.Max(g, b))
+	mn := math.Min(r, math.Min(g, b))
+
+	res := hsl{l: (mx + mn) / 2}
+
+	if d := mx - mn; d > 0 {
+		res.s = d / (1 - math.Abs(2*res.l-1))
+
+		switch mx {
+		case r:
+			res.h = math.Mod((g-b)/d, 6)
+		case g:
+			res.h = (b-r)/d + 2
+		default:
+			res.h = (r-g)/d + 4
+		}
+
+		res.h = math.Mod(res.h*60+360, 360)
+	}
+
+	return res, nil
+}
+
+// hex returns the color hex code of c, in its canonical form.
+func (c hsl) hex() string {
+	h := math.Mod(math.Mod(c.h, 360)+360, 360)
+	s := math.Min(1, math.Max(0, c.s))
+	l := math.Min(1, math.Max(0, c.l))
+
+	ch := (1 - math.Abs(2*l-1)) * s
+	x := ch * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - ch/2
+
+	var r, g, b float64
+
+	switch {
+	case h < 60:
+		r, g, b = ch, x, 0
+	case h < 120:
+		r, g, b = x, ch, 0
+	case h < 180:
+		r, g, b = 0, ch, x
+	case h < 240:
+		r, g, b = 0, x, ch
+	case h < 300:
+		r, g, b = x, 0, ch
+	default:
+		r, g, b = ch, 0, x
+	}
+
+	return fmt.Sprintf("#%02X%02X%02X", int(math.Round((r+m)*255)), int(math.Round((g+m)*255)), int(math.Round((b+m)*255)))
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeColor(t *testing.T) {
+	valid := map[string]string{
+		"#FFFFFF": "#FFFFFF",
+		"#ffffff": "#FFFFFF",
+		"#fff":    "#FFFFFF",
+		"#1a2B3c": "#1A2B3C",
+		"#a1B":    "#AA11BB",
+		"navy":    "#000080",
+		"Navy":    "#000080",
+		" teal ":  "#008080",
+	}
+
+	for c, e := range valid {
+		n, err := normalizeColor(c)
+
+		assert.NoError(t, err, c)
+		assert.Equal(t, e, n, c)
+	}
+
+	for _, c := range []string{"", "#", "#FF", "#FFFF", "#FFFFFFF", "FFFFFF", "#GGGGGG", "notacolor", "rgb(0, 0, 0)"} {
+		_, err := normalizeColor(c)
+		assert.Error(t, err, c)
+	}
+
+	assert.Len(t, cssNamedColors, 148)
+}
+
+func TestSuppressEquivalentColorDiff(t *testing.T) {
+	assert.True(t, suppressEquivalentColorDiff("", "#FFFFFF", "#fff", nil))
+	assert.True(t, suppressEquivalentColorDiff("", "#000080", "navy", nil))
+	assert.True(t, suppressEquivalentColorDiff("", "#00ffff", "aqua", nil))
+	assert.False(t, suppressEquivalentColorDiff("", "#FFFFFF", "#FFFFFE", nil))
+	assert.False(t, suppressEquivalentColorDiff("", "", "#FFFFFF", nil))
+	assert.False(t, suppressEquivalentColorDiff("", "#FFFFFF", "notacolor", nil))
+}
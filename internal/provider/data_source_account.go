@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the information of the OneSpan Sign account that the provider is configured for.",
+
+		ReadContext: dataSourceAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the account.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"company_id": {
+				Description: "ID of the company the account belongs to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"company_name": {
+				Description: "Name of the company the account belongs to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owner": {
+				Description: "ID of the user owning the account.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Creation date of the account.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"license": {
+				Description: "Licenses of the account.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Description: "Status of the license (e.g. `ACTIVE`).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"plan_id": {
+							Description: "ID of the plan the license was purchased for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"plan_name": {
+							Description: "Name of the plan the license was purchased for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"plan_cycle": {
+							Description: "Billing cycle of the plan (e.g. `MONTH`, `YEAR`).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created": {
+							Description: "Creation date of the license.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"paid_until": {
+							Description: "Date until which the license has been paid for.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"features": {
+				Description: "Features enabled by the active licenses of the account.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func flattenAccountLicenses(ls []ossign.AccountLicense) []interface{} {
+	r := make([]interface{}, len(ls))
+
+	for i, l := range ls {
+		e := make(map[string]interface{}, 6)
+
+		e["status"] = l.Status
+		e["plan_id"] = l.Plan.Id
+		e["plan_name"] = l.Plan.Name
+		e["plan_cycle"] = l.Plan.Cycle
+		e["created"] = l.Created
+		e["paid_until"] = l.PaidUntil
+
+		r[i] = e
+	}
+
+	return r
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+
+	a, apiErr := c.GetAccount()
+
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if err := d.Set("name", a.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("company_id", a.Company.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("company_name", a.Company.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owner", a.Owner); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("created", a.Created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("license", flattenAccountLicenses(a.Licenses)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("features", a.Features()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(a.Id)
+
+	return diags
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceAccountSigningLogos reads the logos with their image. Unlike the resource, which only keeps the hash
+// of the configured images, the data source has no configuration to take the images from.
+func dataSourceAccountSigningLogos() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the signing logos of the OneSpan Sign account.",
+
+		ReadContext: dataSourceAccountSigningLogosRead,
+
+		Schema: map[string]*schema.Schema{
+			"logo": {
+				Description: "Customized logo used during the Signing Ceremony.",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"language": {
+							Description: "The language of the Signing Ceremony where the image is used.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"image": {
+							Description: "Base 64 decoded image (Data URI).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"image_sha256": {
+							Description: "Hex-encoded SHA-256 hash of the image, as in `onespansign_account_signing_logos`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenDataSourceSigningLogos flattens the logos with their image and its hash.
+func flattenDataSourceSigningLogos(logos []ossign.SigningLogo) []interface{} {
+	ls := make([]interface{}, len(logos))
+
+	for i, v := range logos {
+		ls[i] = map[string]interface{}{
+			"language":     v.Language,
+			"image":        v.Image,
+			"image_sha256": imageSha256(v.Image),
+		}
+	}
+
+	return ls
+}
+
+func dataSourceAccountSigningLogosRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	logos, apiErr := m.client.GetAccountSigningLogos()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if err := d.Set("logo", flattenDataSourceSigningLogos(logos)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	d.SetId(id)
+
+	return nil
+}
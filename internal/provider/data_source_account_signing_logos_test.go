@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceAccountSigningLogos(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`data "onespansign_account_signing_logos" "foo" {}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.onespansign_account_signing_logos.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.onespansign_account_signing_logos.foo", "logo.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenDataSourceSigningLogos(t *testing.T) {
+	ls := flattenDataSourceSigningLogos([]ossign.SigningLogo{
+		{Language: "en", Image: testImg},
+	})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"language":     "en",
+			"image":        testImg,
+			"image_sha256": imageSha256(testImg),
+		},
+	}, ls)
+
+	// The data source has no input attributes of the resource
+	s := dataSourceAccountSigningLogos().Schema
+	assert.NotContains(t, s, "default_image")
+	e := s["logo"].Elem.(*schema.Resource).Schema
+	assert.NotContains(t, e, "source")
+	assert.NotContains(t, e, "optimize")
+}
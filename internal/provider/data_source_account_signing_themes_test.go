@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAccountSigningThemes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`data "onespansign_account_signing_themes" "foo" {}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.onespansign_account_signing_themes.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.onespansign_account_signing_themes.foo", "theme.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCeremonyPreview() *schema.Resource {
+	ts := signingThemeSchema()
+	delete(ts, "sections")
+	ts["name"].Required = false
+	ts["name"].Optional = true
+	ts["name"].Default = "preview"
+	ts["name"].Description = "Name of the theme, shown in the preview. Defaults to `preview`."
+
+	return &schema.Resource{
+		Description: "Renders a static HTML mock of the Signing Ceremony with a signing theme and logos, e.g. to attach it to the " +
+			"review of a theme or logo change. The preview is rendered locally, nothing is read from or written to the account. " +
+			"It also lists the contrast of the theme colors with the ceremony's text.",
+
+		ReadContext: dataSourceCeremonyPreviewRead,
+
+		Schema: map[string]*schema.Schema{
+			"theme": {
+				Description: "Signing theme to preview, as in `onespansign_account_signing_themes`.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: ts,
+				},
+			},
+			"logo": {
+				Description: "Logo to preview for a language.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"language": {
+							Description:      "Language of the Signing Ceremony using the logo.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+						},
+						"image": {
+							Description:      "Base 64 decoded image (Data URI). Exactly one of `image` or `source` must be specified.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: isValidImageData,
+						},
+						"source": {
+							Description:      "Path to the image file. Exactly one of `image` or `source` must be specified.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: isValidImageSource,
+						},
+					},
+				},
+			},
+			"languages": {
+				Description: "Languages of the Signing Ceremony to render, in order. Defaults to the languages of the logos, " +
+					"or `en` if there are no logos.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				},
+			},
+			"html": {
+				Description: "Standalone HTML document of the preview.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// getCeremonyPreview builds the preview from the resource data, reading the logo images from their files if needed.
+func getCeremonyPreview(d *schema.ResourceData) (ceremonyPreview, error) {
+	t := d.Get("theme").([]interface{})[0].(map[string]interface{})
+
+	p := ceremonyPreview{
+		Theme:  t["name"].(string),
+		Colors: themeColors(t),
+		Logos:  make(map[string]string),
+	}
+
+	for _, item := range d.Get("logo").([]interface{}) {
+		i := item.(map[string]interface{})
+		l := i["language"].(string)
+
+		if (i["image"].(string) == "") == (i["source"].(string) == "") {
+			return p, fmt.Errorf("exactly one of `image` or `source` must be specified for the %q logo", l)
+		}
+
+		if _, ok := p.Logos[l]; ok {
+			return p, fmt.Errorf("the %q logo is specified more than once", l)
+		}
+
+		img := i["image"].(string)
+
+		if s := i["source"].(string); s != "" {
+			var err error
+			if img, err = imageFileDataUri(s); err != nil {
+				return p, fmt.Errorf("invalid %q logo: %w", l, err)
+			}
+		}
+
+		p.Logos[l] = img
+		p.Languages = append(p.Languages, l)
+	}
+
+	if ls := d.Get("languages").([]interface{}); len(ls) > 0 {
+		p.Languages = make([]string, len(ls))
+
+		for i, l := range ls {
+			p.Languages[i] = l.(string)
+		}
+	}
+
+	if len(p.Languages) == 0 {
+		p.Languages = []string{"en"}
+	}
+
+	return p, nil
+}
+
+func dataSourceCeremonyPreviewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	p, err := getCeremonyPreview(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	h, err := p.render()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("html", h); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The preview only depends on its arguments
+	d.SetId(sha256Hex([]byte(h)))
+
+	return nil
+}
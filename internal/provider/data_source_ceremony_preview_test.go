@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestAccDataSourceCeremonyPreview(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "logo.png")
+	if err := ioutil.WriteFile(src, d.Data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				data "onespansign_ceremony_preview" "foo" {
+					theme {
+						name = "brand"
+						primary = "navy"
+						success = "#2E7D32"
+						warning = "#F2C200"
+						error = "#C62828"
+						info = "#1565C0"
+						signature_button = "#1A4F9C"
+						optional_signature_button = "#5C6BC0"
+					}
+
+					logo {
+						language = "en"
+						image = "%s"
+					}
+
+					logo {
+						language = "fr"
+						source = "%s"
+					}
+				}
+				`, testImg, src)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.onespansign_ceremony_preview.foo", "id", regexp.MustCompile("^[0-9a-f]{64}$")),
+					resource.TestMatchResourceAttr("data.onespansign_ceremony_preview.foo", "html", regexp.MustCompile(`border-bottom: 4px solid #000080`)),
+					resource.TestMatchResourceAttr("data.onespansign_ceremony_preview.foo", "html", regexp.MustCompile(`alt="Logo \(en\)"`)),
+					resource.TestMatchResourceAttr("data.onespansign_ceremony_preview.foo", "html", regexp.MustCompile(`alt="Logo \(fr\)"`)),
+				),
+			},
+			{
+				Config: getTestConfig(`
+				data "onespansign_ceremony_preview" "foo" {
+					theme {
+						primary = "navy"
+						success = "#2E7D32"
+						warning = "#F2C200"
+						error = "#C62828"
+						info = "#1565C0"
+						signature_button = "#1A4F9C"
+						optional_signature_button = "#5C6BC0"
+					}
+
+					logo {
+						language = "en"
+					}
+				}
+				`),
+				ExpectError: regexp.MustCompile("exactly one of `image` or `source` must be specified"),
+			},
+		},
+	})
+}
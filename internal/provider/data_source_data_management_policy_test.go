@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDataManagementPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`data "onespansign_data_management_policy" "foo" {}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.onespansign_data_management_policy.foo", "id"),
+					resource.TestCheckResourceAttr("data.onespansign_data_management_policy.foo", "transaction_retention.#", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,9 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceExpiryTimeConfig() *schema.Resource {
+	return expiryTimeConfigSingleton().DataSource()
+}
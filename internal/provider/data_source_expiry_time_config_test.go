@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceExpiryTimeConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`data "onespansign_expiry_time_config" "foo" {}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.onespansign_expiry_time_config.foo", "id"),
+					resource.TestCheckResourceAttrSet("data.onespansign_expiry_time_config.foo", "default"),
+					resource.TestCheckResourceAttrSet("data.onespansign_expiry_time_config.foo", "maximum"),
+				),
+			},
+		},
+	})
+}
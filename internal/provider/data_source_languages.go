@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLanguages() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the languages of the Signing Ceremony supported by OneSpan Sign, as known to the provider. " +
+			"The API doesn't document a way to retrieve the languages enabled for the account.",
+
+		ReadContext: dataSourceLanguagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"languages": {
+				Description: "Codes of the languages supported by OneSpan Sign (e.g. `en`, `zh-CN`).",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLanguagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	if err := d.Set("languages", supportedLanguages(m)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, apiErr := m.getAccountId()
+
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	d.SetId(id)
+
+	return nil
+}
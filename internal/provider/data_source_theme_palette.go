@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	paletteStrategyMonochromatic = "monochromatic"
+	paletteStrategyComplementary = "complementary"
+	paletteStrategyTriadic       = "triadic"
+)
+
+// paletteHues are the hues, in degrees, of the notification colors. They keep their usual meaning whatever
+// the primary color.
+var paletteHues = map[string]float64{
+	"success": 130,
+	"warning": 42,
+	"error":   2,
+	"info":    205,
+}
+
+func dataSourceThemePalette() *schema.Resource {
+	return &schema.Resource{
+		Description: "Generates the colors of a signing theme from a primary color. The palette is computed locally, " +
+			"its colors are adjusted to meet the accessibility level and can be used as-is in `onespansign_account_signing_themes` " +
+			"or `onespansign_account_signing_theme`.",
+
+		ReadContext: dataSourceThemePaletteRead,
+
+		Schema: map[string]*schema.Schema{
+			"primary": {
+				Description:      "Primary color of the theme, as a hex code or a CSS named color.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateColor,
+			},
+			"strategy": {
+				Description: fmt.Sprintf("How the signature button colors are derived from the primary color: `%s` uses shades of the primary color, "+
+					"`%s` uses its complementary color and `%s` uses the two other colors of its triad. Defaults to `%s`.",
+					paletteStrategyMonochromatic, paletteStrategyComplementary, paletteStrategyTriadic, paletteStrategyMonochromatic),
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  paletteStrategyMonochromatic,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					paletteStrategyMonochromatic, paletteStrategyComplementary, paletteStrategyTriadic,
+				}, false)),
+			},
+			"accessibility_level": {
+				Description: fmt.Sprintf("WCAG 2.1 level that the contrast of the generated colors with the ceremony's text must meet: `%s` or `%s`. Defaults to `%s`.",
+					accessibilityLevelAA, accessibilityLevelAAA, accessibilityLevelAA),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          accessibilityLevelAA,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{accessibilityLevelAA, accessibilityLevelAAA}, false)),
+			},
+			"success": {
+				Description: "Success notification color hex code.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"warning": {
+				Description: "Warning notification color hex code.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"error": {
+				Description: "Error notification color hex code.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"info": {
+				Description: "Info notification color hex code.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"signature_button": {
+				Description: "Color hex code for the required signature buttons.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"optional_signature_button": {
+				Description: "Color hex code for the optional signature buttons.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// generateThemePalette generates the other colors of a theme, keyed by attribute name, from the primary color p.
+// The colors are adjusted to meet the accessibility level with the ceremony's text.
+func generateThemePalette(p string, strategy string, level string) (map[string]string, error) {
+	c, err := parseHsl(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// The notifications follow the saturation of the brand, within readable bounds
+	s := math.Min(0.85, math.Max(0.45, c.s))
+
+	r := map[string]hsl{
+		"success": {h: paletteHues["success"], s: s, l: 0.45},
+		"warning": {h: paletteHues["warning"], s: s, l: 0.55},
+		"error":   {h: paletteHues["error"], s: s, l: 0.45},
+		"info":    {h: paletteHues["info"], s: s, l: 0.45},
+	}
+
+	switch strategy {
+	case paletteStrategyComplementary:
+		r["signature_button"] = hsl{h: c.h + 180, s: c.s, l: c.l}
+		r["optional_signature_button"] = hsl{h: c.h + 180, s: c.s * 0.4, l: c.l}
+	case paletteStrategyTriadic:
+		r["signature_button"] = hsl{h: c.h + 120, s: c.s, l: c.l}
+		r["optional_signature_button"] = hsl{h: c.h + 240, s: c.s, l: c.l}
+	default:
+		// The optional buttons are a darker shade, so that they differ by more than their hue
+		r["signature_button"] = c
+		r["optional_signature_button"] = hsl{h: c.h, s: c.s * 0.4, l: c.l * 0.6}
+	}
+
+	res := make(map[string]string, len(r))
+
+	for k, v := range r {
+		res[k] = fitContrast(v, themeColorTexts[k], minContrastRatios[level])
+	}
+
+	return res, nil
+}
+
+// fitContrast returns the color hex code of c with the lightness closest to its own that has at least the contrast
+// ratio minRatio with the text color t. Colors are darkened under white text and lightened under black text.
+func fitContrast(c hsl, t string, minRatio float64) string {
+	meets := func(v hsl) bool {
+		cr, err := contrastRatio(v.hex(), t)
+		return err == nil && cr >= minRatio
+	}
+
+	if meets(c) {
+		return c.hex()
+	}
+
+	// Black and white have the highest contrast with white and black text
+	lo, hi := 0.0, c.l
+	if t != "#FFFFFF" {
+		lo, hi = c.l, 1
+	}
+
+	for i := 0; i < 24; i++ {
+		m := c
+		m.l = (lo + hi) / 2
+
+		if meets(m) == (t == "#FFFFFF") {
+			lo = m.l
+		} else {
+			hi = m.l
+		}
+	}
+
+	if t == "#FFFFFF" {
+		c.l = lo
+	} else {
+		c.l = hi
+	}
+
+	return c.hex()
+}
+
+func dataSourceThemePaletteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	p := d.Get("primary").(string)
+	strategy := d.Get("strategy").(string)
+	level := d.Get("accessibility_level").(string)
+
+	r, err := generateThemePalette(p, strategy, level)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for k, v := range r {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The palette only depends on its arguments
+	d.SetId(fmt.Sprintf("%s/%s/%s", normalizeColorOrKeep(p), strategy, level))
+
+	return nil
+}
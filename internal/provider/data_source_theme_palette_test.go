@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceThemePalette(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`
+				data "onespansign_theme_palette" "foo" {
+					primary = "#1a4f9c"
+				}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.onespansign_theme_palette.foo", "id", "#1A4F9C/monochromatic/AA"),
+					resource.TestCheckResourceAttr("data.onespansign_theme_palette.foo", "signature_button", "#1A4F9C"),
+					resource.TestMatchResourceAttr("data.onespansign_theme_palette.foo", "success", regexp.MustCompile("^#[0-9A-F]{6}$")),
+					resource.TestMatchResourceAttr("data.onespansign_theme_palette.foo", "warning", regexp.MustCompile("^#[0-9A-F]{6}$")),
+					resource.TestMatchResourceAttr("data.onespansign_theme_palette.foo", "error", regexp.MustCompile("^#[0-9A-F]{6}$")),
+					resource.TestMatchResourceAttr("data.onespansign_theme_palette.foo", "info", regexp.MustCompile("^#[0-9A-F]{6}$")),
+					resource.TestMatchResourceAttr("data.onespansign_theme_palette.foo", "optional_signature_button", regexp.MustCompile("^#[0-9A-F]{6}$")),
+				),
+			},
+		},
+	})
+}
+
+func TestHsl(t *testing.T) {
+	for _, c := range []string{"#000000", "#FFFFFF", "#FF0000", "#00FF00", "#0000FF", "#1A4F9C", "#F2C200", "#808080", "#C71585"} {
+		h, err := parseHsl(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c, h.hex())
+	}
+
+	h, err := parseHsl("navy")
+	assert.NoError(t, err)
+	assert.InDelta(t, 240, h.h, 0.01)
+	assert.InDelta(t, 1, h.s, 0.01)
+	assert.InDelta(t, 0.25, h.l, 0.01)
+
+	_, err = parseHsl("notacolor")
+	assert.Error(t, err)
+}
+
+func TestGenerateThemePalette(t *testing.T) {
+	for _, p := range []string{"#1A4F9C", "#FFEB3B", "#E91E63", "#000000", "#FFFFFF", "lightyellow", "#00C853"} {
+		for _, s := range []string{paletteStrategyMonochromatic, paletteStrategyComplementary, paletteStrategyTriadic} {
+			for _, l := range []string{accessibilityLevelAA, accessibilityLevelAAA} {
+				r, err := generateThemePalette(p, s, l)
+
+				assert.NoError(t, err)
+				assert.Len(t, r, 6)
+				assert.Empty(t, checkThemeContrast(r, l), fmt.Sprintf("%s/%s/%s", p, s, l))
+
+				// The palette is deterministic
+				r2, _ := generateThemePalette(p, s, l)
+				assert.Equal(t, r, r2)
+			}
+		}
+	}
+
+	// Accessible brand colors are kept
+	r, err := generateThemePalette("#1A4F9C", paletteStrategyMonochromatic, accessibilityLevelAA)
+	assert.NoError(t, err)
+	assert.Equal(t, "#1A4F9C", r["signature_button"])
+	assert.Empty(t, checkThemeColorVision(r))
+
+	// Light brand colors are darkened until they meet the accessibility level
+	r, err = generateThemePalette("lightyellow", paletteStrategyMonochromatic, accessibilityLevelAA)
+	assert.NoError(t, err)
+
+	h, _ := parseHsl(r["signature_button"])
+	assert.InDelta(t, 60, h.h, 5)
+
+	cr, _ := contrastRatio(r["signature_button"], "#FFFFFF")
+	assert.InDelta(t, 4.5, cr, 0.2)
+
+	_, err = generateThemePalette("notacolor", paletteStrategyMonochromatic, accessibilityLevelAA)
+	assert.Error(t, err)
+}
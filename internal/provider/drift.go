@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getDriftValues flattens the values of the top-level attributes ks of the resource data into a map of
+// attribute paths (e.g. "transaction_retention[0].sent") to their string representation.
+//
+// When ks is nil, the attributes of s that are present in the current state are used. This excludes
+// the attributes that were never stored, e.g. when the resource is being imported.
+func getDriftValues(d *schema.ResourceData, s map[string]*schema.Schema, ks []string) (map[string]string, []string) {
+	if ks == nil {
+		st := d.State()
+
+		for k := range s {
+			if st == nil {
+				break
+			}
+
+			if _, ok := st.Attributes[k]; ok {
+				ks = append(ks, k)
+			} else if _, ok := st.Attributes[k+".#"]; ok {
+				ks = append(ks, k)
+			}
+		}
+	}
+
+	r := make(map[string]string)
+
+	for _, k := range ks {
+		flattenDriftValue(k, d.Get(k), r)
+	}
+
+	return r, ks
+}
+
+func flattenDriftValue(p string, v interface{}, r map[string]string) {
+	switch t := v.(type) {
+	case *schema.Set:
+		flattenDriftValue(p, t.List(), r)
+
+	case []interface{}:
+		for i, e := range t {
+			flattenDriftValue(fmt.Sprintf("%s[%d]", p, i), e, r)
+		}
+
+	case map[string]interface{}:
+		for k, e := range t {
+			flattenDriftValue(fmt.Sprintf("%s.%s", p, k), e, r)
+		}
+
+	default:
+		r[p] = fmt.Sprintf("%v", t)
+	}
+}
+
+// getDriftDiags reports the attributes whose values differ between the prior state o and the remote value n.
+// The diagnostic is an error when failOnDrift is true, a warning otherwise.
+func getDriftDiags(name string, o map[string]string, n map[string]string, failOnDrift bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var changes []string
+
+	for k, ov := range o {
+		if nv, ok := n[k]; !ok {
+			changes = append(changes, fmt.Sprintf("  - %s: %q (removed)", k, ov))
+		} else if nv != ov {
+			changes = append(changes, fmt.Sprintf("  - %s: %q => %q", k, ov, nv))
+		}
+	}
+
+	for k, nv := range n {
+		if _, ok := o[k]; !ok {
+			changes = append(changes, fmt.Sprintf("  - %s: %q (added)", k, nv))
+		}
+	}
+
+	if len(changes) == 0 {
+		return diags
+	}
+
+	sort.Strings(changes)
+
+	severity := diag.Warning
+	if failOnDrift {
+		severity = diag.Error
+	}
+
+	return append(diags, diag.Diagnostic{
+		Severity: severity,
+		Summary:  fmt.Sprintf("the account's %s was changed outside of Terraform", name),
+		Detail:   fmt.Sprintf("The following attributes differ from the last known state:\n%s", strings.Join(changes, "\n")),
+	})
+}
+
+// appendConfiguredPaths appends the paths of the values set in the configuration value v at the path p, in the
+// format of getDriftValues. The paths of sets and unknown values cover all their elements.
+func appendConfiguredPaths(ps []string, p string, v cty.Value) []string {
+	if v.IsNull() {
+		return ps
+	}
+
+	if !v.IsKnown() || v.Type().IsSetType() {
+		return append(ps, p)
+	}
+
+	switch {
+	case v.Type().IsObjectType(), v.Type().IsMapType():
+		for k, e := range v.AsValueMap() {
+			ps = appendConfiguredPaths(ps, fmt.Sprintf("%s.%s", p, k), e)
+		}
+
+	case v.Type().IsListType(), v.Type().IsTupleType():
+		for i, e := range v.AsValueSlice() {
+			ps = appendConfiguredPaths(ps, fmt.Sprintf("%s[%d]", p, i), e)
+		}
+
+	default:
+		ps = append(ps, p)
+	}
+
+	return ps
+}
+
+// filterConfiguredDrift removes the values of the attributes that are not covered by the configured paths ps from
+// the drift values vs.
+func filterConfiguredDrift(vs map[string]string, ps []interface{}) {
+	for k := range vs {
+		if !isConfiguredPath(k, ps) {
+			delete(vs, k)
+		}
+	}
+}
+
+// isConfiguredPath reports whether the attribute at the path k is covered by the configured paths ps.
+func isConfiguredPath(k string, ps []interface{}) bool {
+	for _, p := range ps {
+		p := p.(string)
+		if k == p || strings.HasPrefix(k, p+".") || strings.HasPrefix(k, p+"[") {
+			return true
+		}
+	}
+
+	return false
+}
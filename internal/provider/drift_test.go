@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenDriftValue(t *testing.T) {
+	r := make(map[string]string)
+
+	flattenDriftValue("transaction_retention", []interface{}{
+		map[string]interface{}{
+			"sent":         30,
+			"include_sent": false,
+		},
+	}, r)
+
+	assert.Equal(t, map[string]string{
+		"transaction_retention[0].sent":         "30",
+		"transaction_retention[0].include_sent": "false",
+	}, r)
+}
+
+func TestGetDriftDiags(t *testing.T) {
+	o := map[string]string{
+		"default": "10",
+		"maximum": "30",
+	}
+
+	assert.Empty(t, getDriftDiags("expiry time configuration", o, o, false))
+
+	n := map[string]string{
+		"default": "10",
+		"maximum": "40",
+	}
+
+	diags := getDriftDiags("expiry time configuration", o, n, false)
+
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, `maximum: "30" => "40"`)
+	assert.NotContains(t, diags[0].Detail, "default")
+
+	diags = getDriftDiags("expiry time configuration", o, n, true)
+
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+}
+
+func TestAppendConfiguredPaths(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"transaction_retention": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"sent":  cty.NumberIntVal(30),
+				"draft": cty.NullVal(cty.Number),
+				"tags":  cty.SetVal([]cty.Value{cty.StringVal("a")}),
+			}),
+		}),
+		"default": cty.UnknownVal(cty.Number),
+		"maximum": cty.NullVal(cty.Number),
+	})
+
+	var ps []string
+	for k := range v.Type().AttributeTypes() {
+		ps = appendConfiguredPaths(ps, k, v.GetAttr(k))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"default",
+		"transaction_retention[0].sent",
+		"transaction_retention[0].tags",
+	}, ps)
+}
+
+func TestFilterConfiguredDrift(t *testing.T) {
+	vs := map[string]string{
+		"transaction_retention[0].sent":    "30",
+		"transaction_retention[0].draft":   "10",
+		"transaction_retention[0].tags[0]": "a",
+		"transaction_retention[0].sentx":   "1",
+	}
+
+	filterConfiguredDrift(vs, []interface{}{"transaction_retention[0].sent", "transaction_retention[0].tags"})
+
+	assert.Equal(t, map[string]string{
+		"transaction_retention[0].sent":    "30",
+		"transaction_retention[0].tags[0]": "a",
+	}, vs)
+}
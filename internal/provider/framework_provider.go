@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// frameworkProvider is the terraform-plugin-framework implementation of the provider. It is served alongside
+// the SDKv2 provider returned by New, so that resources can be ported to the framework one at a time.
+//
+// Its schema must exactly match the schema of the SDKv2 provider.
+type frameworkProvider struct {
+	version string
+
+	// shared holds the meta shared with the SDKv2 provider.
+	shared *sharedProviderMeta
+
+	// meta is set once the provider is configured, it is shared by the resources of the provider.
+	meta *providerMeta
+}
+
+// frameworkProviderModel maps the provider schema to Go values.
+type frameworkProviderModel struct {
+	EnvironmentUrl types.String `tfsdk:"environment_url"`
+	ClientId       types.String `tfsdk:"client_id"`
+	ClientSecret   types.String `tfsdk:"client_secret"`
+	FailOnDrift    types.Bool   `tfsdk:"fail_on_drift"`
+	LogoMaxWidth   types.Int64  `tfsdk:"logo_max_width"`
+	LogoMaxHeight  types.Int64  `tfsdk:"logo_max_height"`
+}
+
+var _ tfsdk.Provider = &frameworkProvider{}
+
+func NewFramework(version string) func() tfsdk.Provider {
+	return func() tfsdk.Provider {
+		return newFrameworkProvider(version, &sharedProviderMeta{})
+	}
+}
+
+// newFrameworkProvider creates the plugin framework provider, whose meta is shared with the other providers
+// through s.
+func newFrameworkProvider(version string, s *sharedProviderMeta) *frameworkProvider {
+	return &frameworkProvider{
+		version: version,
+		shared:  s,
+	}
+}
+
+func (p *frameworkProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"environment_url": {
+				Type:                types.StringType,
+				Required:            true,
+				MarkdownDescription: "Environment URL for the OneSpan sign account.",
+			},
+			"client_id": {
+				Type:                types.StringType,
+				Required:            true,
+				MarkdownDescription: "Client ID of the client app created for this provider.",
+			},
+			"client_secret": {
+				Type:                types.StringType,
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Client secret of the client app created for this provider.",
+			},
+			"fail_on_drift": {
+				Type:                types.BoolType,
+				Optional:            true,
+				MarkdownDescription: "Report changes made to the account settings outside of Terraform as errors instead of warnings when refreshing resources.",
+			},
+			"logo_max_width": {
+				Type:                types.Int64Type,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum width, in pixels, of the raster signing logos. Defaults to `%d`.", defaultLogoMaxWidth),
+			},
+			"logo_max_height": {
+				Type:                types.Int64Type,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum height, in pixels, of the raster signing logos. Defaults to `%d`.", defaultLogoMaxHeight),
+			},
+		},
+	}, nil
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
+	var c frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &c)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := p.shared.configure(providerConfig{
+		EnvironmentUrl: c.EnvironmentUrl.Value,
+		ClientId:       c.ClientId.Value,
+		ClientSecret:   c.ClientSecret.Value,
+		FailOnDrift:    c.FailOnDrift.Value,
+		LogoMaxWidth:   int(c.LogoMaxWidth.Value),
+		LogoMaxHeight:  int(c.LogoMaxHeight.Value),
+		UserAgent:      fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-onespan-sign/%s", req.TerraformVersion, p.version),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("unable to configure the provider", err.Error())
+		return
+	}
+
+	p.meta = m
+}
+
+func (p *frameworkProvider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{}, nil
+}
+
+func (p *frameworkProvider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{}, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// languageCodePattern matches the codes of the languages of the Signing Ceremony (e.g. "en" or "zh-CN").
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+
+// supportedLanguages returns the codes of the languages supported by OneSpan Sign. The API doesn't document a way
+// to retrieve the languages enabled for the account, the languages known to the provider are used instead.
+func supportedLanguages(m *providerMeta) []string {
+	return m.languages
+}
+
+// checkLanguage checks that the language l is supported by OneSpan Sign. Languages added to OneSpan Sign after
+// the release of the provider are reported as plan warnings, since they can't be checked against the API.
+func checkLanguage(ctx context.Context, l string, m *providerMeta) {
+	ls := supportedLanguages(m)
+
+	for _, v := range ls {
+		if v == l {
+			return
+		}
+	}
+
+	addPlanWarning(ctx, fmt.Sprintf("Unknown %q language", l),
+		fmt.Sprintf("The %q language is not among the languages of the Signing Ceremony known to the provider: %s. "+
+			"The API doesn't document a way to retrieve the languages enabled for the account, so the language can't be "+
+			"checked: the apply fails if it isn't enabled for the account.", l, strings.Join(ls, ", ")))
+}
@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vincent-petithory/dataurl"
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+)
+
+const (
+	// defaultLogoMaxWidth and defaultLogoMaxHeight are the default maximum dimensions, in pixels, of the
+	// raster logos. Larger logos are scaled down by the Signing Ceremony and render poorly.
+	defaultLogoMaxWidth  = 800
+	defaultLogoMaxHeight = 300
+
+	// maxLogoSize is the maximum size, in bytes, of the signing logos accepted by OneSpan Sign.
+	maxLogoSize = 1_000_000
+)
+
+// imageSha256 returns the hex-encoded SHA-256 hash of the image of the data URI v. The hash is computed on
+// the decoded image, so that the same image has the same hash whether it's given as a data URI or a file.
+// The data URI itself is hashed when it can't be decoded.
+func imageSha256(v string) string {
+	if d, err := dataurl.DecodeString(v); err == nil {
+		return sha256Hex(d.Data)
+	}
+
+	return sha256Hex([]byte(v))
+}
+
+// sourceSha256 returns the hex-encoded SHA-256 hash of the image file at path p. The path itself is hashed
+// when the file can't be read, the error is reported by the validation of the attribute.
+func sourceSha256(p string) string {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return sha256Hex([]byte(p))
+	}
+
+	return sha256Hex(b)
+}
+
+// sha256HexPattern matches the hex-encoded SHA-256 hashes stored in the state in place of the images.
+var sha256HexPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// readImageFile reads the image file at path p and detects its content type, first from its content and
+// then from its extension for formats that can't be sniffed (e.g. SVG).
+func readImageFile(p string) (string, []byte, error) {
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ct := http.DetectContentType(b)
+
+	if !strings.HasPrefix(ct, "image/") {
+		if t := mime.TypeByExtension(strings.ToLower(filepath.Ext(p))); t != "" {
+			ct = t
+		}
+	}
+
+	if t, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = t
+	}
+
+	return ct, b, nil
+}
+
+// imageFileDataUri returns the image file at path p as a base64 data URI.
+func imageFileDataUri(p string) (string, error) {
+	ct, b, err := readImageFile(p)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the image file %s: %w", p, err)
+	}
+
+	return dataurl.New(b, ct).String(), nil
+}
+
+// checkImageContent checks that the content of the image matches its declared content type. Raster images are
+// identified by their magic number, and SVG images must be safe to display to the signers.
+func checkImageContent(contentType string, data []byte) error {
+	if contentType == "image/svg+xml" {
+		return checkSvg(data)
+	}
+
+	if t := http.DetectContentType(data); t != contentType {
+		return fmt.Errorf("the content of the image doesn't match its content type %s, it was detected as %s", contentType, t)
+	}
+
+	return nil
+}
+
+// svgExternalCssPattern matches the CSS constructs referencing external resources.
+var svgExternalCssPattern = regexp.MustCompile(`(?i)@import|url\(\s*['"]?\s*[^#'"\s)]`)
+
+// svgUrlSchemePattern matches the values starting with a URL scheme (e.g. "javascript:").
+var svgUrlSchemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// svgAnimationElements are the SMIL elements that change the attributes of their target element.
+var svgAnimationElements = map[string]bool{
+	"set":              true,
+	"animate":          true,
+	"animatecolor":     true,
+	"animatemotion":    true,
+	"animatetransform": true,
+}
+
+// checkSvg checks that data is an SVG image without scripts, event handlers or references to external resources.
+// Only references to elements of the image and embedded raster images are allowed.
+func checkSvg(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	root := true
+	inStyle := false
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to parse the SVG image: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.Directive:
+			if bytes.Contains(bytes.ToUpper(t), []byte("ENTITY")) {
+				return errors.New("SVG images cannot declare entities")
+			}
+		case xml.StartElement:
+			n := strings.ToLower(t.Name.Local)
+
+			if root && n != "svg" {
+				return fmt.Errorf("the root element of the SVG image is <%s> instead of <svg>", t.Name.Local)
+			}
+			root = false
+
+			if n == "script" || n == "foreignobject" {
+				return fmt.Errorf("SVG images cannot contain <%s> elements", t.Name.Local)
+			}
+
+			inStyle = n == "style"
+
+			for _, a := range t.Attr {
+				if err := checkSvgAttr(t.Name.Local, a); err != nil {
+					return err
+				}
+			}
+
+			if svgAnimationElements[n] {
+				if err := checkSvgAnimation(t); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			inStyle = false
+		case xml.CharData:
+			if inStyle && svgExternalCssPattern.Match(t) {
+				return errors.New("the styles of SVG images cannot reference external resources")
+			}
+		}
+	}
+
+	if root {
+		return errors.New("the image is not an SVG image")
+	}
+
+	return nil
+}
+
+func checkSvgAttr(e string, a xml.Attr) error {
+	n := strings.ToLower(a.Name.Local)
+	v := strings.TrimSpace(a.Value)
+
+	if strings.HasPrefix(n, "on") {
+		return fmt.Errorf("SVG images cannot contain event handlers, found %q on <%s>", a.Name.Local, e)
+	}
+
+	if n == "href" || n == "src" {
+		lv := strings.ToLower(v)
+		if !strings.HasPrefix(lv, "#") && !(strings.HasPrefix(lv, "data:image/") && !strings.HasPrefix(lv, "data:image/svg")) {
+			return fmt.Errorf("SVG images cannot reference external resources, found %q on <%s>", v, e)
+		}
+	}
+
+	if svgExternalCssPattern.MatchString(v) {
+		return fmt.Errorf("SVG images cannot reference external resources, found %q on <%s>", v, e)
+	}
+
+	return nil
+}
+
+// checkSvgAnimation checks that the animation element e doesn't set links or event handlers on its target, which
+// would bypass the checks of the attributes, and that its values don't hold URLs.
+func checkSvgAnimation(e xml.StartElement) error {
+	for _, a := range e.Attr {
+		n := strings.ToLower(a.Name.Local)
+
+		switch n {
+		case "attributename":
+			// The attribute may be prefixed, e.g. "xlink:href"
+			t := strings.ToLower(strings.TrimSpace(a.Value))
+			if i := strings.LastIndex(t, ":"); i >= 0 {
+				t = t[i+1:]
+			}
+
+			if t == "href" || t == "src" || strings.HasPrefix(t, "on") {
+				return fmt.Errorf("SVG images cannot animate the %q attribute, found on <%s>", a.Value, e.Name.Local)
+			}
+
+		case "to", "from", "by", "values":
+			for _, v := range strings.Split(a.Value, ";") {
+				if v := strings.TrimSpace(v); svgUrlSchemePattern.MatchString(v) {
+					return fmt.Errorf("SVG images cannot animate attributes to URLs, found %q on <%s>", v, e.Name.Local)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkImageDimensions checks that the raster image data is at most maxWidth x maxHeight pixels. SVG images are
+// scalable and are not checked.
+func checkImageDimensions(contentType string, data []byte, maxWidth int, maxHeight int) error {
+	if contentType == "image/svg+xml" {
+		return nil
+	}
+
+	c, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to decode the image: %w", err)
+	}
+
+	if c.Width > maxWidth || c.Height > maxHeight {
+		return fmt.Errorf("the image is %dx%d pixels, the maximum is %dx%d pixels", c.Width, c.Height, maxWidth, maxHeight)
+	}
+
+	return nil
+}
+
+// optimizeImage re-encodes the PNG, JPEG or GIF image data so that it fits in maxWidth x maxHeight pixels and
+// in maxLogoSize bytes. The image is downscaled to fit the dimensions, and encoded as JPEG images of decreasing
+// quality when it's still too large. Images that already fit and other formats are returned as-is.
+//
+// The optimization is deterministic, so that the same input always produces the same image.
+func optimizeImage(contentType string, data []byte, maxWidth int, maxHeight int) (string, []byte, error) {
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return contentType, data, nil
+	}
+
+	c, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to decode the image: %w", err)
+	}
+
+	if c.Width <= maxWidth && c.Height <= maxHeight && len(data) <= maxLogoSize {
+		return contentType, data, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to decode the image: %w", err)
+	}
+
+	img = fitImage(img, maxWidth, maxHeight)
+
+	var buf bytes.Buffer
+
+	switch contentType {
+	case "image/png":
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, img)
+	case "image/gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to encode the image: %w", err)
+	}
+
+	if contentType != "image/jpeg" && buf.Len() <= maxLogoSize {
+		return contentType, buf.Bytes(), nil
+	}
+
+	// JPEG images have no transparency, transparent pixels are rendered on the white background of the ceremony
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	for q := 90; q >= 10; q -= 10 {
+		buf.Reset()
+
+		if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: q}); err != nil {
+			return "", nil, fmt.Errorf("unable to encode the image: %w", err)
+		}
+
+		if buf.Len() <= maxLogoSize {
+			return "image/jpeg", buf.Bytes(), nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("unable to optimize the image to less than 1MB, got: %d", buf.Len())
+}
+
+// fitImage downscales img to fit in maxWidth x maxHeight pixels, keeping its aspect ratio.
+func fitImage(img image.Image, maxWidth int, maxHeight int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w <= maxWidth && h <= maxHeight {
+		return img
+	}
+
+	r := float64(maxWidth) / float64(w)
+	if rh := float64(maxHeight) / float64(h); rh < r {
+		r = rh
+	}
+
+	nw := int(float64(w)*r + 0.5)
+	if nw < 1 {
+		nw = 1
+	}
+
+	nh := int(float64(h)*r + 0.5)
+	if nh < 1 {
+		nh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, nw, nh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+
+	return dst
+}
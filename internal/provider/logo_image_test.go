@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestImageSha256(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := filepath.Join(t.TempDir(), "logo.png")
+	if err := ioutil.WriteFile(p, d.Data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, imageSha256(testImg), 64)
+	assert.Equal(t, imageSha256(testImg), sourceSha256(p))
+	assert.NotEqual(t, sourceSha256(p), sourceSha256(p+".missing"))
+}
+
+func TestReadImageFile(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+
+	for n, c := range map[string]struct {
+		data        []byte
+		contentType string
+	}{
+		"logo.bin": {d.Data, "image/png"},
+		"logo.svg": {[]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`), "image/svg+xml"},
+		"logo.txt": {[]byte("not an image"), "text/plain"},
+	} {
+		p := filepath.Join(dir, n)
+		if err := ioutil.WriteFile(p, c.data, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		ct, b, err := readImageFile(p)
+
+		assert.NoError(t, err, n)
+		assert.Equal(t, c.contentType, ct, n)
+		assert.Equal(t, c.data, b, n)
+	}
+
+	p := filepath.Join(dir, "logo.bin")
+
+	uri, err := imageFileDataUri(p)
+	assert.NoError(t, err)
+	assert.Equal(t, imageSha256(testImg), imageSha256(uri))
+
+	_, err = imageFileDataUri(filepath.Join(dir, "missing.png"))
+	assert.Error(t, err)
+}
+
+func TestCheckImageContent(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, checkImageContent("image/png", d.Data))
+	assert.Error(t, checkImageContent("image/jpeg", d.Data))
+	assert.Error(t, checkImageContent("image/png", []byte("<svg></svg>")))
+	assert.Error(t, checkImageContent("image/svg+xml", d.Data))
+}
+
+func TestCheckSvg(t *testing.T) {
+	valid := []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><rect width="10" height="10" fill="#000"/></svg>`,
+		`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><defs><linearGradient id="g"/></defs><rect fill="url(#g)"/><use href="#g"/></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><image xlink:href="data:image/png;base64,AAAA"/></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><style>rect { fill: red; }</style></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><rect><animate attributeName="opacity" values="0;1" dur="1s"/><set attributeName="fill" to="#F00"/></rect></svg>`,
+	}
+
+	for _, v := range valid {
+		assert.NoError(t, checkSvg([]byte(v)), v)
+	}
+
+	invalid := []string{
+		`<html><body></body></html>`,
+		`not xml`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><foreignObject><div/></foreignObject></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><a href="javascript:alert(1)"><rect/></a></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><image xlink:href="https://example.com/logo.png"/></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><image href="data:image/svg+xml;base64,AAAA"/></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><rect fill="url(https://example.com/p.svg#p)"/></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><style>@import url(https://example.com/a.css);</style></svg>`,
+		`<!DOCTYPE svg [<!ENTITY x SYSTEM "file:///etc/passwd">]><svg xmlns="http://www.w3.org/2000/svg">&x;</svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><a><set attributeName="href" to="javascript:alert(1)"/><rect/></a></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><a><animate attributeName="href" values="javascript:alert(1)"/><rect/></a></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a><set attributeName="xlink:href" to="#a"/><rect/></a></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><rect><set attributeName="onclick" to="alert(1)"/></rect></svg>`,
+		`<svg xmlns="http://www.w3.org/2000/svg"><rect><animate attributeName="x" from="javascript:alert(1)" to="1"/></rect></svg>`,
+	}
+
+	for _, v := range invalid {
+		assert.Error(t, checkSvg([]byte(v)), v)
+	}
+}
+
+func TestCheckImageDimensions(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// testImg is 10x13 pixels
+	assert.NoError(t, checkImageDimensions("image/png", d.Data, 10, 13))
+	assert.Error(t, checkImageDimensions("image/png", d.Data, 9, 13))
+	assert.Error(t, checkImageDimensions("image/png", d.Data, 10, 12))
+	assert.Error(t, checkImageDimensions("image/png", []byte("not an image"), 10, 13))
+	assert.NoError(t, checkImageDimensions("image/svg+xml", []byte("<svg></svg>"), 1, 1))
+}
+
+// noiseImage generates a w x h image of pseudo-random pixels, which doesn't compress well.
+func noiseImage(w int, h int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	x := uint32(1)
+	for i := range img.Pix {
+		x = x*1664525 + 1013904223
+		img.Pix[i] = uint8(x >> 24)
+	}
+
+	return img
+}
+
+func TestOptimizeImage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, noiseImage(1600, 900)); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, buf.Len(), maxLogoSize)
+
+	ct, b, err := optimizeImage("image/png", buf.Bytes(), 800, 300)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(b), maxLogoSize)
+	assert.NoError(t, checkImageContent(ct, b))
+	assert.NoError(t, checkImageDimensions(ct, b, 800, 300))
+
+	c, _, err := image.DecodeConfig(bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, 533, c.Width)
+	assert.Equal(t, 300, c.Height)
+
+	// The optimization is deterministic
+	ct2, b2, err := optimizeImage("image/png", buf.Bytes(), 800, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, ct, ct2)
+	assert.Equal(t, b, b2)
+
+	// Images that fit are left untouched
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct, b, err = optimizeImage("image/png", d.Data, 800, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", ct)
+	assert.Equal(t, d.Data, b)
+
+	ct, b, err = optimizeImage("image/svg+xml", []byte("<svg></svg>"), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", ct)
+	assert.Equal(t, []byte("<svg></svg>"), b)
+
+	_, _, err = optimizeImage("image/png", []byte("not an image"), 800, 300)
+	assert.Error(t, err)
+}
+
+func TestFitImage(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 100, 50), fitImage(noiseImage(100, 50), 800, 300).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 800, 200), fitImage(noiseImage(1600, 400), 800, 300).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 3, 300), fitImage(noiseImage(10, 1000), 800, 300).Bounds())
+}
+
+func TestFlattenSigningLogoImage(t *testing.T) {
+	h := imageSha256(testImg)
+
+	// Import
+	e := map[string]interface{}{}
+	flattenSigningLogoImage(e, testImg, nil, false)
+	assert.Equal(t, map[string]interface{}{"image": h, "image_sha256": h, "optimize": false}, e)
+
+	// The configured image was optimized before being written
+	p := map[string]interface{}{"image": "", "source": "source-hash", "image_sha256": h, "optimize": true}
+	e = map[string]interface{}{}
+	flattenSigningLogoImage(e, testImg, p, false)
+	assert.Equal(t, map[string]interface{}{"source": "source-hash", "image_sha256": h, "optimize": true}, e)
+
+	// Right after the image was written, the prior state holds the hash of the image written before
+	p["image_sha256"] = "previous-hash"
+	e = map[string]interface{}{}
+	flattenSigningLogoImage(e, testImg, p, true)
+	assert.Equal(t, map[string]interface{}{"source": "source-hash", "image_sha256": h, "optimize": true}, e)
+
+	// The image was changed outside of Terraform
+	e = map[string]interface{}{}
+	flattenSigningLogoImage(e, testImg, p, false)
+	assert.Equal(t, map[string]interface{}{"source": h, "image_sha256": h, "optimize": true}, e)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// NewMuxServer combines the SDKv2 provider and the plugin framework provider into a single provider server.
+// Each resource and data source is served by the provider that implements it. The SDKv2 provider is wrapped
+// by planWarningsServer, so that its resources can report warnings in their plans. Both providers share the
+// same meta once configured.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	s := &sharedProviderMeta{}
+
+	providers := []func() tfprotov5.ProviderServer{
+		func() tfprotov5.ProviderServer {
+			return planWarningsServer{newProvider(version, s).GRPCProvider()}
+		},
+		providerserver.NewProtocol5(newFrameworkProvider(version, s)),
+	}
+
+	m, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ProviderServer, nil
+}
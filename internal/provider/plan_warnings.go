@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// planWarningsKey is the context key of the planWarnings of the resource being planned.
+type planWarningsKey struct{}
+
+// planWarnings collects the warnings reported while planning a resource.
+type planWarnings struct {
+	mu    sync.Mutex
+	diags []*tfprotov5.Diagnostic
+}
+
+// addPlanWarning reports a warning in the plan of the resource planned with ctx. The CustomizeDiff functions of
+// the SDK can only return errors, so the warnings are added to the plan by planWarningsServer. They are only logged
+// when ctx doesn't come from it.
+func addPlanWarning(ctx context.Context, summary string, detail string) {
+	w, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		tflog.Warn(ctx, summary, map[string]interface{}{
+			"detail": detail,
+		})
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.diags = append(w.diags, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// planWarningsServer serves the SDK provider, adding the warnings reported with addPlanWarning to the plans.
+type planWarningsServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s planWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	w := &planWarnings{}
+
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, w), req)
+
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, w.diags...)
+	}
+
+	return resp, err
+}
@@ -2,10 +2,9 @@ package provider
 
 import (
 	"context"
-	"net/url"
+	"fmt"
 	"regexp"
 
-	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -29,57 +28,93 @@ func init() {
 
 func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
-		p := &schema.Provider{
-			Schema: map[string]*schema.Schema{
-				"environment_url": {
-					Type:             schema.TypeString,
-					Required:         true,
-					Description:      "Environment URL for the OneSpan sign account.",
-					ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile("^https://(www.)?[a-zA-Z0-9.-]{2,256}.[a-z]{2,4}$"), "Please provide a valid environment URL in the format of <scheme>://<host>")),
-				},
-				"client_id": {
-					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Client ID of the client app created for this provider.",
-				},
-				"client_secret": {
-					Type:        schema.TypeString,
-					Required:    true,
-					Sensitive:   true,
-					Description: "Client secret of the client app created for this provider.",
-				},
+		return newProvider(version, &sharedProviderMeta{})
+	}
+}
+
+// newProvider creates the SDKv2 provider, whose meta is shared with the other providers through s.
+func newProvider(version string, s *sharedProviderMeta) *schema.Provider {
+	p := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"environment_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Environment URL for the OneSpan sign account.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile("^https://(www.)?[a-zA-Z0-9.-]{2,256}.[a-z]{2,4}$"), "Please provide a valid environment URL in the format of <scheme>://<host>")),
 			},
-			DataSourcesMap: map[string]*schema.Resource{},
-			ResourcesMap: map[string]*schema.Resource{
-				"onespansign_account_signing_logos":  resourceAccountSigningLogos(),
-				"onespansign_account_signing_themes": resourceAccountSigningThemes(),
-				"onespansign_data_management_policy": resourceDataManagementPolicy(),
-				"onespansign_expiry_time_config":     resourceExpiryTimeConfig(),
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Client ID of the client app created for this provider.",
 			},
-		}
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Client secret of the client app created for this provider.",
+			},
+			"fail_on_drift": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Report changes made to the account settings outside of Terraform as errors instead of warnings when refreshing resources.",
+			},
+			"logo_max_width": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      fmt.Sprintf("Maximum width, in pixels, of the raster signing logos. Defaults to `%d`.", defaultLogoMaxWidth),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"logo_max_height": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      fmt.Sprintf("Maximum height, in pixels, of the raster signing logos. Defaults to `%d`.", defaultLogoMaxHeight),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"onespansign_account":                dataSourceAccount(),
+			"onespansign_account_signing_logos":  dataSourceAccountSigningLogos(),
+			"onespansign_account_signing_themes": dataSourceAccountSigningThemes(),
+			"onespansign_ceremony_preview":       dataSourceCeremonyPreview(),
+			"onespansign_data_management_policy": dataSourceDataManagementPolicy(),
+			"onespansign_expiry_time_config":     dataSourceExpiryTimeConfig(),
+			"onespansign_languages":              dataSourceLanguages(),
+			"onespansign_theme_palette":          dataSourceThemePalette(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"onespansign_account_active_signing_theme": resourceAccountActiveSigningTheme(),
+			"onespansign_account_signing_logo":         resourceAccountSigningLogo(),
+			"onespansign_account_signing_logos":        resourceAccountSigningLogos(),
+			"onespansign_account_signing_theme":        resourceAccountSigningTheme(),
+			"onespansign_account_signing_themes":       resourceAccountSigningThemes(),
+			"onespansign_data_management_policy":       resourceDataManagementPolicy(),
+			"onespansign_expiry_time_config":           resourceExpiryTimeConfig(),
+			"onespansign_sender":                       resourceSender(),
+		},
+	}
 
-		p.ConfigureContextFunc = configure(version, p)
+	p.ConfigureContextFunc = configure(version, p, s)
 
-		return p
-	}
+	return p
 }
 
-func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func configure(version string, p *schema.Provider, s *sharedProviderMeta) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		eu := d.Get("environment_url").(string)
-		id := d.Get("client_id").(string)
-		secret := d.Get("client_secret").(string)
+		m, err := s.configure(providerConfig{
+			EnvironmentUrl: d.Get("environment_url").(string),
+			ClientId:       d.Get("client_id").(string),
+			ClientSecret:   d.Get("client_secret").(string),
+			FailOnDrift:    d.Get("fail_on_drift").(bool),
+			LogoMaxWidth:   d.Get("logo_max_width").(int),
+			LogoMaxHeight:  d.Get("logo_max_height").(int),
+			UserAgent:      p.UserAgent("terraform-provider-onespan-sign", version),
+		})
 
-		url, err := url.Parse(eu)
 		if err != nil {
-			panic(err)
+			return nil, diag.FromErr(err)
 		}
 
-		return ossign.NewClient(ossign.ApiClientConfig{
-			BaseUrl:      url,
-			ClientId:     id,
-			ClientSecret: secret,
-			UserAgent:    p.UserAgent("terraform-provider-onespan-sign", version),
-		}), nil
+		return m, nil
 	}
 }
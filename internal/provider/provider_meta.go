@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+)
+
+// providerMeta is the value returned by the provider's configure function. It is passed as the meta
+// argument of the resources' functions.
+type providerMeta struct {
+	client *ossign.ApiClient
+
+	// failOnDrift reports changes made outside of Terraform as errors instead of warnings.
+	failOnDrift bool
+
+	// logoMaxWidth and logoMaxHeight are the maximum dimensions, in pixels, of the raster signing logos.
+	logoMaxWidth  int
+	logoMaxHeight int
+
+	mu        sync.Mutex
+	accountId string
+
+	// languages are the codes of the languages of the Signing Ceremony known to the provider, see supportedLanguages.
+	languages []string
+
+	// singletons holds the description of the singleton resources planned during the current run, keyed by
+	// resource type and account ID.
+	singletons map[string]string
+
+	// planned holds values planned during the current run that other resources validate their plans against,
+	// see setPlanned.
+	planned map[string]interface{}
+
+	// locks serializes the read-modify-write operations of resources sharing the same remote setting.
+	locks map[string]*sync.Mutex
+}
+
+// providerConfig holds the configuration of the provider, which is shared between the SDKv2 provider
+// and the plugin framework provider.
+type providerConfig struct {
+	EnvironmentUrl string
+	ClientId       string
+	ClientSecret   string
+	FailOnDrift    bool
+	LogoMaxWidth   int
+	LogoMaxHeight  int
+	UserAgent      string
+}
+
+// newConfiguredProviderMeta creates the meta of a provider configured with c.
+func newConfiguredProviderMeta(c providerConfig) (*providerMeta, error) {
+	url, err := url.Parse(c.EnvironmentUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newProviderMeta(ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     c.ClientId,
+		ClientSecret: c.ClientSecret,
+		UserAgent:    c.UserAgent,
+	}))
+
+	m.failOnDrift = c.FailOnDrift
+
+	if c.LogoMaxWidth > 0 {
+		m.logoMaxWidth = c.LogoMaxWidth
+	}
+
+	if c.LogoMaxHeight > 0 {
+		m.logoMaxHeight = c.LogoMaxHeight
+	}
+
+	return m, nil
+}
+
+// sharedProviderMeta holds the meta shared by the providers served together by the mux server, so that the locks,
+// the singleton claims and the planned values are shared by all their resources.
+type sharedProviderMeta struct {
+	mu   sync.Mutex
+	meta *providerMeta
+}
+
+// configure returns the shared meta, which is created with c by the first provider configured.
+func (s *sharedProviderMeta) configure(c providerConfig) (*providerMeta, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.meta == nil {
+		m, err := newConfiguredProviderMeta(c)
+		if err != nil {
+			return nil, err
+		}
+
+		s.meta = m
+	}
+
+	return s.meta, nil
+}
+
+func newProviderMeta(c *ossign.ApiClient) *providerMeta {
+	ls := make([]string, len(ossign.Languages))
+	for i, l := range ossign.Languages {
+		ls[i] = string(l)
+	}
+
+	return &providerMeta{
+		client:        c,
+		logoMaxWidth:  defaultLogoMaxWidth,
+		logoMaxHeight: defaultLogoMaxHeight,
+		languages:     ls,
+		singletons:    make(map[string]string),
+		planned:       make(map[string]interface{}),
+		locks:         make(map[string]*sync.Mutex),
+	}
+}
+
+// getAccountId retrieves the ID of the OneSpan Sign account that the provider is configured for.
+// The account is only fetched once for the lifetime of the provider.
+func (m *providerMeta) getAccountId() (string, *ossign.ApiError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.accountId != "" {
+		return m.accountId, nil
+	}
+
+	a, apiErr := m.client.GetAccount()
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	m.accountId = a.Id
+
+	return m.accountId, nil
+}
+
+// claimSingleton records that an instance of the singleton resource type t, described by desc, manages the
+// account a. It returns false and the description of the other instance when another instance of the same type
+// already claimed the account during the run.
+func (m *providerMeta) claimSingleton(t string, a string, desc string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := t + "/" + a
+
+	if o, ok := m.singletons[k]; ok {
+		return o, false
+	}
+
+	m.singletons[k] = desc
+
+	return "", true
+}
+
+// setPlanned records the value v, planned during the current run, under the key k.
+func (m *providerMeta) setPlanned(k string, v interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.planned[k] = v
+}
+
+// getPlanned returns the value planned under the key k during the current run, if it was planned already.
+func (m *providerMeta) getPlanned(k string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.planned[k]
+
+	return v, ok
+}
+
+// lock acquires the lock named k, which is shared by all the resources of the provider. It returns the
+// function releasing the lock.
+func (m *providerMeta) lock(k string) func() {
+	m.mu.Lock()
+	l, ok := m.locks[k]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[k] = l
+	}
+	m.mu.Unlock()
+
+	l.Lock()
+
+	return l.Unlock
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimSingleton(t *testing.T) {
+	m := newProviderMeta(nil)
+
+	_, ok := m.claimSingleton("onespansign_expiry_time_config", "account1", "first")
+	assert.True(t, ok)
+
+	_, ok = m.claimSingleton("onespansign_expiry_time_config", "account2", "second")
+	assert.True(t, ok)
+
+	_, ok = m.claimSingleton("onespansign_data_management_policy", "account1", "third")
+	assert.True(t, ok)
+
+	o, ok := m.claimSingleton("onespansign_expiry_time_config", "account1", "fourth")
+	assert.False(t, ok)
+	assert.Equal(t, "first", o)
+}
+
+func TestLock(t *testing.T) {
+	m := newProviderMeta(nil)
+
+	var wg sync.WaitGroup
+	n := 0
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock := m.lock("signing_logos")
+			defer unlock()
+
+			v := n
+			n = v + 1
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 50, n)
+
+	// Locks with different names don't block each other
+	unlock := m.lock("signing_logos")
+	m.lock("signing_themes")()
+	unlock()
+}
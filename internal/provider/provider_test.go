@@ -1,22 +1,39 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/gocty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
+// protoV5ProviderFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"onespansign": func() (*schema.Provider, error) {
-		return New("dev")(), nil
+// to create a provider server to which the CLI can reattach. The muxed server is used,
+// so that the resources of both the SDKv2 and the plugin framework providers are available.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"onespansign": func() (tfprotov5.ProviderServer, error) {
+		s, err := NewMuxServer(context.Background(), "dev")
+		if err != nil {
+			return nil, err
+		}
+		return s(), nil
 	},
 }
 
@@ -75,3 +92,302 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestMuxServer(t *testing.T) {
+	// The mux server fails to be created when the schemas of the providers differ
+	if _, err := NewMuxServer(context.Background(), "dev"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testPlanResourceChange plans the resource type typ with the gRPC server of the SDK, as Terraform does, from the
+// prior state and the configuration. The provider is configured with m. The values are converted to the implied type
+// of the resource schema with testCtyValue, a nil prior state plans the creation of the resource.
+func testPlanResourceChange(t *testing.T, m *providerMeta, typ string, prior map[string]interface{}, config map[string]interface{}) (cty.Value, []*tfprotov5.Diagnostic) {
+	p := New("dev")()
+	p.SetMeta(m)
+
+	ty := p.ResourcesMap[typ].CoreConfigSchema().ImpliedType()
+
+	encode := func(v cty.Value) *tfprotov5.DynamicValue {
+		b, err := msgpack.Marshal(v, ty)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &tfprotov5.DynamicValue{MsgPack: b}
+	}
+
+	var pv cty.Value
+	if prior == nil {
+		pv = cty.NullVal(ty)
+	} else {
+		pv = testCtyValue(ty, prior)
+	}
+
+	cv := testCtyValue(ty, config)
+
+	res, err := planWarningsServer{schema.NewGRPCProviderServer(p)}.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         typ,
+		PriorState:       encode(pv),
+		ProposedNewState: encode(testProposedNewState(pv, cv, p.ResourcesMap[typ].Schema)),
+		Config:           encode(cv),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.PlannedState == nil {
+		return cty.NullVal(ty), res.Diagnostics
+	}
+
+	v, err := msgpack.Unmarshal(res.PlannedState.MsgPack, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v, res.Diagnostics
+}
+
+// testApplyResourceChange applies the planned state of the resource type typ with the gRPC server of the SDK, as
+// Terraform does, from the prior state and the configuration. The provider is configured with m. It returns the new
+// state, see testPlanResourceChange for the values.
+func testApplyResourceChange(t *testing.T, m *providerMeta, typ string, prior map[string]interface{}, planned cty.Value, config map[string]interface{}) (cty.Value, []*tfprotov5.Diagnostic) {
+	p := New("dev")()
+	p.SetMeta(m)
+
+	ty := p.ResourcesMap[typ].CoreConfigSchema().ImpliedType()
+
+	encode := func(v cty.Value) *tfprotov5.DynamicValue {
+		b, err := msgpack.Marshal(v, ty)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &tfprotov5.DynamicValue{MsgPack: b}
+	}
+
+	var pv cty.Value
+	if prior == nil {
+		pv = cty.NullVal(ty)
+	} else {
+		pv = testCtyValue(ty, prior)
+	}
+
+	res, err := schema.NewGRPCProviderServer(p).ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     typ,
+		PriorState:   encode(pv),
+		PlannedState: encode(planned),
+		Config:       encode(testCtyValue(ty, config)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.NewState == nil {
+		return cty.NullVal(ty), res.Diagnostics
+	}
+
+	v, err := msgpack.Unmarshal(res.NewState.MsgPack, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v, res.Diagnostics
+}
+
+// testUpgradeResourceState upgrades the JSON state raw of the resource type typ, stored with the schema version v,
+// with the gRPC server of the SDK, as Terraform does. It returns the state decoded with the current schema.
+func testUpgradeResourceState(t *testing.T, typ string, v int64, raw string) cty.Value {
+	p := New("dev")()
+
+	res, err := schema.NewGRPCProviderServer(p).UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: typ,
+		Version:  v,
+		RawState: &tfprotov5.RawState{JSON: []byte(raw)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range res.Diagnostics {
+		t.Fatalf("error upgrading the state: %s: %s", d.Summary, d.Detail)
+	}
+
+	s, err := msgpack.Unmarshal(res.UpgradedState.MsgPack, p.ResourcesMap[typ].CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// testProposedNewState approximates the proposed new state computed by Terraform: the configured values, with the
+// computed attributes of the schema s that are not configured taken from the prior state.
+func testProposedNewState(prior cty.Value, config cty.Value, s map[string]*schema.Schema) cty.Value {
+	if prior.IsNull() || !config.Type().IsObjectType() {
+		return config
+	}
+
+	vals := make(map[string]cty.Value)
+
+	for k := range config.Type().AttributeTypes() {
+		c := config.GetAttr(k)
+
+		if sc, ok := s[k]; c.IsNull() && (k == "id" || ok && sc.Computed) {
+			vals[k] = prior.GetAttr(k)
+		} else {
+			vals[k] = c
+		}
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+// testCtyValue converts the Go value v, made of maps, slices and primitive values, to a value of the type ty.
+// Missing object attributes are null.
+func testCtyValue(ty cty.Type, v interface{}) cty.Value {
+	if v == nil {
+		return cty.NullVal(ty)
+	}
+
+	switch {
+	case ty.IsObjectType():
+		m := v.(map[string]interface{})
+		vals := make(map[string]cty.Value, len(ty.AttributeTypes()))
+
+		for k, at := range ty.AttributeTypes() {
+			vals[k] = testCtyValue(at, m[k])
+		}
+
+		return cty.ObjectVal(vals)
+
+	case ty.IsListType(), ty.IsSetType():
+		l := v.([]interface{})
+		vals := make([]cty.Value, len(l))
+
+		for i, e := range l {
+			vals[i] = testCtyValue(ty.ElementType(), e)
+		}
+
+		switch {
+		case ty.IsListType() && len(vals) == 0:
+			return cty.ListValEmpty(ty.ElementType())
+		case ty.IsListType():
+			return cty.ListVal(vals)
+		case len(vals) == 0:
+			return cty.SetValEmpty(ty.ElementType())
+		default:
+			return cty.SetVal(vals)
+		}
+
+	case ty.IsMapType():
+		m := v.(map[string]interface{})
+		vals := make(map[string]cty.Value, len(m))
+
+		for k, e := range m {
+			vals[k] = testCtyValue(ty.ElementType(), e)
+		}
+
+		if len(vals) == 0 {
+			return cty.MapValEmpty(ty.ElementType())
+		}
+
+		return cty.MapVal(vals)
+	}
+
+	r, err := gocty.ToCtyValue(v, ty)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// testApiClient returns a client of a fake API, which responds to the GET requests of the paths of res with their
+// JSON encoded value. The PUT and POST requests of these paths replace their value in res with the decoded body.
+// The other requests fail.
+func testApiClient(t *testing.T, res map[string]interface{}) *ossign.ApiClient {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/apitoken/clientApp/accessToken" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"accessToken": "token",
+				"expiresAt":   9999999999,
+			})
+			return
+		}
+
+		v, ok := res[r.URL.Path]
+		if !ok || (r.Method != "GET" && r.Method != "PUT" && r.Method != "POST") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != "GET" {
+			d := json.NewDecoder(r.Body)
+			d.UseNumber()
+
+			if err := d.Decode(&v); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			res[r.URL.Path] = v
+		}
+
+		json.NewEncoder(w).Encode(v)
+	}))
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ossign.NewClient(ossign.ApiClientConfig{BaseUrl: u})
+}
+
+func TestMuxSharedMeta(t *testing.T) {
+	ctx := context.Background()
+	s := &sharedProviderMeta{}
+
+	p := newProvider("dev", s)
+
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"environment_url": "https://sandbox.esignlive.com",
+		"client_id":       "client",
+		"client_secret":   "secret",
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring the SDKv2 provider: %v", diags)
+	}
+
+	fp := newFrameworkProvider("dev", s)
+
+	fs, fdiags := fp.GetSchema(ctx)
+	if fdiags.HasError() {
+		t.Fatalf("error getting the framework provider schema: %v", fdiags)
+	}
+
+	var resp tfsdk.ConfigureProviderResponse
+	fp.Configure(ctx, tfsdk.ConfigureProviderRequest{
+		Config: tfsdk.Config{
+			Schema: fs,
+			Raw: tftypes.NewValue(fs.TerraformType(ctx), map[string]tftypes.Value{
+				"environment_url": tftypes.NewValue(tftypes.String, "https://sandbox.esignlive.com"),
+				"client_id":       tftypes.NewValue(tftypes.String, "client"),
+				"client_secret":   tftypes.NewValue(tftypes.String, "secret"),
+				"fail_on_drift":   tftypes.NewValue(tftypes.Bool, nil),
+				"logo_max_width":  tftypes.NewValue(tftypes.Number, nil),
+				"logo_max_height": tftypes.NewValue(tftypes.Number, nil),
+			}),
+		},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("error configuring the framework provider: %v", resp.Diagnostics)
+	}
+
+	// The locks, the singleton claims and the planned values are shared by the resources of both providers
+	assert.NotNil(t, fp.meta)
+	assert.Same(t, p.Meta(), fp.meta)
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const activeSigningThemeTypeName = "onespansign_account_active_signing_theme"
+
+func resourceAccountActiveSigningTheme() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Signing theme of the OneSpan Sign account used by the Signing Ceremony.\n\n" +
+			"The Signing Ceremony uses the first theme returned by the API, the selected theme is moved before the others. " +
+			"The API doesn't document that it returns the themes in the order they were written: when it returns another " +
+			"theme first once the themes are written, their original order is restored and the apply fails. Destroying the " +
+			"resource leaves the themes as they are.\n\n" +
+			singletonDescriptionNote,
+
+		CreateContext: resourceAccountActiveSigningThemeUpdate,
+		ReadContext:   resourceAccountActiveSigningThemeRead,
+		UpdateContext: resourceAccountActiveSigningThemeUpdate,
+		DeleteContext: resourceAccountActiveSigningThemeDelete,
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return claimSingletonDiff(d, meta, activeSigningThemeTypeName)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importSingletonId(d, meta.(*providerMeta)); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Name of the theme used by the Signing Ceremony. The theme must exist in the account.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+		},
+	}
+}
+
+func resourceAccountActiveSigningThemeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ts, apiErr := meta.(*providerMeta).client.GetAccountSigningThemes()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if len(ts) == 0 {
+		tflog.Warn(ctx, "the account has no signing themes, removing the active signing theme from the state")
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", ts[0].Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountActiveSigningThemeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	n := d.Get("name").(string)
+
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	// The original order of the themes, restored when the selected theme isn't returned first once written
+	var o []string
+
+	diags := updateSigningThemes(ctx, m, func(ts ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics) {
+		if _, ok := ts.Get(n); !ok {
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("the %q signing theme does not exist", n),
+					Detail:   fmt.Sprintf("The account has no signing theme named %q, the existing themes are %q.", n, ts.Names()),
+				},
+			}
+		}
+
+		o = ts.Names()
+
+		return orderSigningThemes(ts, []string{n}), nil
+	})
+
+	if diags.HasError() {
+		return diags
+	}
+
+	ts, apiErr := m.client.GetAccountSigningThemes()
+	if apiErr != nil {
+		return append(diags, apiErrorDiags(apiErr)...)
+	}
+
+	if c := checkActiveSigningTheme(ts, n); c.HasError() {
+		// The resource isn't saved to the state, the account is not left with the themes reordered
+		tflog.Warn(ctx, "restoring the original order of the signing themes", map[string]interface{}{
+			"order": o,
+		})
+
+		diags = append(diags, c...)
+
+		return append(diags, updateSigningThemes(ctx, m, func(ts ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics) {
+			return orderSigningThemes(ts, o), nil
+		})...)
+	}
+
+	d.SetId(id)
+
+	return append(diags, resourceAccountActiveSigningThemeRead(ctx, d, meta)...)
+}
+
+// orderSigningThemes returns the themes ts named in ns first, in the order of ns, followed by the other themes.
+func orderSigningThemes(ts ossign.SigningThemes, ns []string) ossign.SigningThemes {
+	r := make(ossign.SigningThemes, 0, len(ts))
+	ordered := make(map[string]bool, len(ns))
+
+	for _, n := range ns {
+		if t, ok := ts.Get(n); ok && !ordered[n] {
+			r = append(r, ossign.NamedSigningTheme{Name: n, Theme: t})
+			ordered[n] = true
+		}
+	}
+
+	for _, v := range ts {
+		if !ordered[v.Name] {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
+// checkActiveSigningTheme checks that the theme named n is the first of the themes ts returned by the API, i.e.
+// the theme used by the Signing Ceremony. The order of the themes is not guaranteed by the API, so the themes are
+// checked once the API reflects their content instead of waiting for the order to change.
+func checkActiveSigningTheme(ts ossign.SigningThemes, n string) diag.Diagnostics {
+	if len(ts) > 0 && ts[0].Name == n {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("the %q signing theme is not used by the Signing Ceremony", n),
+			Detail: fmt.Sprintf("The %q theme was written before the other themes, but the API returns the themes in the order %q, "+
+				"and the Signing Ceremony uses the first one. The original order of the themes is restored. The API doesn't guarantee "+
+				"the order of the themes, keep a single theme in the account with `onespansign_account_signing_themes` instead.", n, ts.Names()),
+		},
+	}
+}
+
+func resourceAccountActiveSigningThemeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "no deletion will take place",
+			Detail:   "The Signing Ceremony always uses one of the account's themes, the current active theme is kept.",
+		},
+	}
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// signingLogosLock is the name of the provider lock serializing the writes to the account's signing logos.
+const signingLogosLock = "signing_logos"
+
+func resourceAccountSigningLogo() *schema.Resource {
+	r := &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Customized logo of the OneSpan Sign account used during the Signing Ceremony of a single language.\n\n" +
+			"Only the logo of its language is managed by the resource, so that the logos of the other languages can be " +
+			"managed separately. It should not be used together with `onespansign_account_signing_logos`, which manages " +
+			"the logos of all the languages.",
+
+		CreateContext: resourceAccountSigningLogoCreate,
+		ReadContext:   resourceAccountSigningLogoRead,
+		UpdateContext: resourceAccountSigningLogoUpdate,
+		DeleteContext: resourceAccountSigningLogoDelete,
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if l := d.Get("language").(string); l != "" {
+				checkLanguage(ctx, l, meta.(*providerMeta))
+			}
+
+			return validateSigningLogoImage(d.GetRawConfig(), meta.(*providerMeta))
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAccountSigningLogoImport,
+		},
+
+		Schema: signingLogoSchema(map[string]*schema.Schema{
+			"language": {
+				Description:      "The language of the Signing Ceremony where the image will be used. It must be enabled for the account, the languages unknown to the provider are reported during the plan.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(languageCodePattern, "must be a language code")),
+			},
+		}),
+	}
+
+	r.Schema["image"].ExactlyOneOf = []string{"image", "source"}
+	r.Schema["source"].ExactlyOneOf = []string{"image", "source"}
+
+	return r
+}
+
+// updateSigningLogo replaces the logo of the language l with the image img, or removes it when img is empty.
+// The other logos of the account are left untouched. When create is true, an error is returned if the language
+// already has a logo.
+func updateSigningLogo(ctx context.Context, m *providerMeta, l string, img string, create bool) diag.Diagnostics {
+	unlock := m.lock(signingLogosLock)
+	defer unlock()
+
+	logos, apiErr := m.client.GetAccountSigningLogos()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	b := make([]ossign.SigningLogo, 0, len(logos)+1)
+
+	for _, v := range logos {
+		if v.Language != l {
+			b = append(b, v)
+			continue
+		}
+
+		if create {
+			return diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("the %q signing logo already exists", l),
+					Detail:   fmt.Sprintf("The account already has a customized logo for the %q language. Import it with `terraform import` to manage it.", l),
+				},
+			}
+		}
+	}
+
+	if img != "" {
+		b = append(b, ossign.SigningLogo{
+			Language: l,
+			Image:    img,
+		})
+	}
+
+	tflog.Trace(ctx, "updating the signing logo", map[string]interface{}{
+		"language": l,
+		"delete":   img == "",
+	})
+
+	if apiErr := m.client.UpdateAccountSigningLogos(b); apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	return nil
+}
+
+// getSigningLogo retrieves the logo of the language l. The returned logo is nil when the language doesn't
+// have a customized logo.
+func getSigningLogo(c *ossign.ApiClient, l string) (*ossign.SigningLogo, *ossign.ApiError) {
+	logos, apiErr := c.GetAccountSigningLogos()
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	for _, v := range logos {
+		if v.Language == l {
+			return &v, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceAccountSigningLogoCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	l := d.Get("language").(string)
+
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	img, err := signingLogoImage(d.GetRawConfig(), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := updateSigningLogo(ctx, m, l, img, true); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", id, l))
+
+	return resourceAccountSigningLogoRead(ctx, d, meta)
+}
+
+func resourceAccountSigningLogoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	logo, apiErr := getSigningLogo(c, d.Get("language").(string))
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if logo == nil {
+		tflog.Warn(ctx, "the signing logo was removed outside of Terraform, removing it from the state", map[string]interface{}{
+			"language": d.Get("language").(string),
+		})
+		d.SetId("")
+		return nil
+	}
+
+	p := make(map[string]interface{}, 4)
+	for _, k := range []string{"image", "source", "image_sha256", "optimize"} {
+		p[k] = d.Get(k)
+	}
+
+	e := make(map[string]interface{}, 4)
+	flattenSigningLogoImage(e, logo.Image, p, isSigningLogoWritten(d))
+
+	for _, k := range []string{"image", "source", "image_sha256", "optimize"} {
+		if err := d.Set(k, e[k]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAccountSigningLogoUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	img, err := signingLogoImage(d.GetRawConfig(), meta.(*providerMeta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := updateSigningLogo(ctx, meta.(*providerMeta), d.Get("language").(string), img, false); diags.HasError() {
+		return diags
+	}
+
+	return resourceAccountSigningLogoRead(ctx, d, meta)
+}
+
+func resourceAccountSigningLogoDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return updateSigningLogo(ctx, meta.(*providerMeta), d.Get("language").(string), "", false)
+}
+
+// resourceAccountSigningLogoImport imports the logo of a language. The import ID is the language, optionally
+// prefixed by the account ID or the "account" alias (e.g. "account/fr").
+func resourceAccountSigningLogoImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, apiErr := meta.(*providerMeta).getAccountId()
+	if apiErr != nil {
+		return nil, apiErr.GetError()
+	}
+
+	l := d.Id()
+
+	if i := strings.LastIndex(l, "/"); i >= 0 {
+		if a := l[:i]; a != id && a != importSingletonIdAlias {
+			return nil, fmt.Errorf("the import ID %q does not match the configured OneSpan Sign account %q", d.Id(), id)
+		}
+
+		l = l[i+1:]
+	}
+
+	if err := d.Set("language", l); err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", id, l))
+
+	return []*schema.ResourceData{d}, nil
+}
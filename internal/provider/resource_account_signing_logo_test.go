@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestAccResourceSigningLogo(t *testing.T) {
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "logo.png")
+	if err := ioutil.WriteFile(src, d.Data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSigningLogoDestroyed("de", "ja"),
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_account_signing_logo" "de" {
+					language = "de"
+					image = "%s"
+				}
+
+				resource "onespansign_account_signing_logo" "ja" {
+					language = "ja"
+					source = "%s"
+				}
+				`, testImg, src)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_account_signing_logo.de", "image", imageSha256(testImg)),
+					resource.TestCheckResourceAttr("onespansign_account_signing_logo.de", "image_sha256", imageSha256(testImg)),
+					resource.TestCheckResourceAttr("onespansign_account_signing_logo.ja", "source", imageSha256(testImg)),
+					resource.TestCheckResourceAttr("onespansign_account_signing_logo.ja", "image_sha256", imageSha256(testImg)),
+					testAccCheckSigningLogoExists("de"),
+					testAccCheckSigningLogoExists("ja"),
+				),
+			},
+			{
+				ResourceName:      "onespansign_account_signing_logo.de",
+				ImportState:       true,
+				ImportStateId:     "account/de",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "onespansign_account_signing_logo.de",
+				ImportState:   true,
+				ImportStateId: "not-the-account-id/de",
+				ExpectError:   regexp.MustCompile("does not match the configured OneSpan Sign account"),
+			},
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_account_signing_logo" "de" {
+					language = "de"
+					image = "%s"
+				}
+				`, testImg)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSigningLogoExists("de"),
+					testAccCheckSigningLogoDestroyed("ja"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSigningLogoExists(l string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		logo, apiErr := getSigningLogo(getTestApiClient(), l)
+		if apiErr != nil {
+			return apiErr.GetError()
+		}
+
+		if logo == nil || imageSha256(logo.Image) != imageSha256(testImg) {
+			return fmt.Errorf("Signing logo %q does not match expectation", l)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSigningLogoDestroyed(ls ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		for _, l := range ls {
+			logo, apiErr := getSigningLogo(getTestApiClient(), l)
+			if apiErr != nil {
+				return apiErr.GetError()
+			}
+
+			if logo != nil {
+				return fmt.Errorf("Signing logo %q still exists", l)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestAccountSigningLogoOptimizeUpdate(t *testing.T) {
+	res := map[string]interface{}{}
+	m := newProviderMeta(testApiClient(t, res))
+	m.accountId = "account1"
+	m.languages = []string{"en"}
+	m.logoMaxWidth = 5
+	m.logoMaxHeight = 5
+
+	img1, opt1, img2, opt2 := testOptimizedLogoImages(t, m)
+
+	res["/api/account/admin/signingLogos"] = []interface{}{
+		map[string]interface{}{"language": "en", "image": opt1},
+	}
+
+	state := func(img string, opt string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":           "account1/en",
+			"language":     "en",
+			"image":        imageSha256(img),
+			"source":       "",
+			"optimize":     true,
+			"image_sha256": imageSha256(opt),
+		}
+	}
+
+	config := map[string]interface{}{
+		"language": "en",
+		"image":    img2,
+		"optimize": true,
+	}
+
+	prior := state(img1, opt1)
+
+	p, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logo", prior, config)
+	assert.Empty(t, diags)
+
+	s, diags := testApplyResourceChange(t, m, "onespansign_account_signing_logo", prior, p, config)
+	assert.Empty(t, diags)
+
+	// The configured image is kept while the written image is the optimized one
+	ty := resourceAccountSigningLogo().CoreConfigSchema().ImpliedType()
+	expected := testCtyValue(ty, state(img2, opt2))
+	assert.True(t, s.RawEquals(expected), "unexpected state: %#v", s)
+
+	p2, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logo", state(img2, opt2), config)
+	assert.Empty(t, diags)
+	assert.True(t, p2.RawEquals(expected), "unexpected plan: %#v", p2)
+}
+
+func TestAccountSigningLogoUnknownLanguage(t *testing.T) {
+	m := newProviderMeta(testApiClient(t, map[string]interface{}{}))
+	m.accountId = "account1"
+	m.languages = []string{"en"}
+
+	config := map[string]interface{}{
+		"language": "xx-YY",
+		"image":    testImg,
+	}
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logo", nil, config)
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+		assert.Equal(t, `Unknown "xx-YY" language`, diags[0].Summary)
+	}
+
+	config["language"] = "en"
+	_, diags = testPlanResourceChange(t, m, "onespansign_account_signing_logo", nil, config)
+	assert.Empty(t, diags)
+}
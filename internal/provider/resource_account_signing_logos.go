@@ -7,7 +7,6 @@ import (
 
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,47 +14,241 @@ import (
 )
 
 func resourceAccountSigningLogos() *schema.Resource {
-	return &schema.Resource{
+	r := accountSigningLogosSingleton()
+
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceAccountSigningLogosV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeAccountSigningLogosImageToSha256,
+		},
+	}
+
+	return r.Resource()
+}
+
+// resourceAccountSigningLogosV0 is the version 0 of the resource, where the `image` attribute held the data URI
+// of the logo and images couldn't be read from files.
+func resourceAccountSigningLogosV0() *schema.Resource {
+	r := accountSigningLogosSingleton().Resource()
+	delete(r.Schema, "default_image")
+
+	e := r.Schema["logo"].Elem.(*schema.Resource)
+	e.Schema["image"].Required = true
+	e.Schema["image"].Optional = false
+	e.Schema["image"].StateFunc = nil
+	delete(e.Schema, "source")
+	delete(e.Schema, "image_sha256")
+	delete(e.Schema, "optimize")
+
+	return r
+}
+
+// upgradeAccountSigningLogosImageToSha256 replaces the data URIs stored in the `image` attribute of the logos
+// by the SHA-256 hash of their image.
+func upgradeAccountSigningLogosImageToSha256(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	logos, _ := rawState["logo"].([]interface{})
+
+	for _, item := range logos {
+		i, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		img, _ := i["image"].(string)
+		i["image"] = imageSha256(img)
+		i["image_sha256"] = i["image"]
+		i["source"] = nil
+		i["optimize"] = false
+	}
+
+	return rawState, nil
+}
+
+func accountSigningLogosSingleton() *singletonResource {
+	return &singletonResource{
+		TypeName: "onespansign_account_signing_logos",
+		Name:     "signing logos",
 		// This description is used by the documentation generator and the language server.
-		Description: `OneSpan Sign account's customized logos used during the Signing Ceremony.
-		
-Please note that this resource is a singleton, which means that only one instance of this resource should
-exist. Having multiple instances may produce unexpected result.`,
+		Description: "OneSpan Sign account's customized logos used during the Signing Ceremony.",
+		OnDestroyNote: " With `restore_original`, the snapshot holds the original images of all the languages, up to 1MB each. " +
+			"It is compressed and hidden from the plans, but it is kept in the state until the resource is destroyed.",
 
-		CreateContext: resourceAccountSigningLogosCreate,
-		ReadContext:   resourceAccountSigningLogosRead,
-		UpdateContext: resourceAccountSigningLogosUpdate,
-		DeleteContext: resourceAccountSigningLogosDelete,
+		Get: func(c *ossign.ApiClient) (interface{}, *ossign.ApiError) {
+			return c.GetAccountSigningLogos()
+		},
+		Set: func(c *ossign.ApiClient, v interface{}) *ossign.ApiError {
+			return c.UpdateAccountSigningLogos(v.([]ossign.SigningLogo))
+		},
+		Flatten:       setAccountSigningLogosResourceData,
+		Expand:        buildAccountSigningLogos,
+		CustomizeDiff: validateAccountSigningLogosDiff,
+		Default:       []ossign.SigningLogo{},
 
 		Schema: map[string]*schema.Schema{
+			"default_image": {
+				Description: "Base 64 decoded image (Data URI) used for all the languages supported by OneSpan Sign that don't have a `logo` block. " +
+					"Only the SHA-256 hash of the image is kept in the state.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: isValidImageData,
+				StateFunc: func(v interface{}) string {
+					return imageSha256(v.(string))
+				},
+			},
 			"logo": {
 				// This description is used by the documentation generator and the language server.
-				Description: "Customized logo used during the Signing Ceremony.",
+				Description: "Customized logo used during the Signing Ceremony. It overrides `default_image` for its language.",
 				Type:        schema.TypeSet,
 				Optional:    true,
+				// The state only holds the hash of the images, the configured logos are hashed with the hash of
+				// their image to match the logos of the state.
+				Set: hashSigningLogo,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
+					Schema: signingLogoSchema(map[string]*schema.Schema{
 						"language": {
-							Description:      "The language of the Signing Ceremony where the image will be used.",
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"en", "fr", "it", "ru", "es", "pt", "de", "nl", "da", "el", "zh-CN", "zh-TW", "ja", "ko"}, false)),
-						},
-						"image": {
-							Description:      "Base 64 decoded image (Data URI).",
+							Description:      "The language of the Signing Ceremony where the image will be used. It must be enabled for the account, the languages unknown to the provider are reported during the plan.",
 							Type:             schema.TypeString,
 							Required:         true,
-							ValidateDiagFunc: isValidImageData,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(languageCodePattern, "must be a language code")),
 						},
-					},
+					}),
 				},
 			},
 		},
+	}
+}
+
+// hashSigningLogo hashes the logo v by its language and its image. The configured image and source are hashed as
+// by their StateFunc, unless v comes from the state where they already are. The hash of the remote image is left
+// out since it is only known once the logo is written.
+func hashSigningLogo(v interface{}) int {
+	i := v.(map[string]interface{})
+
+	stateValue := func(k string, f func(string) string) string {
+		s, _ := i[k].(string)
+		if s == "" || sha256HexPattern.MatchString(s) {
+			return s
+		}
+
+		return f(s)
+	}
+
+	optimize, _ := i["optimize"].(bool)
+
+	return schema.HashString(fmt.Sprintf("%s/%s/%s/%t", i["language"], stateValue("image", imageSha256), stateValue("source", sourceSha256), optimize))
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+// signingLogoSchema adds the attributes describing the image of a logo to the schema s.
+func signingLogoSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["image"] = &schema.Schema{
+		Description: "Base 64 decoded image (Data URI). Only the SHA-256 hash of the image is kept in the state. " +
+			"Exactly one of `image` or `source` must be specified.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: isValidImageData,
+		StateFunc: func(v interface{}) string {
+			return imageSha256(v.(string))
 		},
 	}
+
+	s["source"] = &schema.Schema{
+		Description: "Path to the image file. Its content type is detected from its content or its extension. " +
+			"Only the SHA-256 hash of the image is kept in the state. Exactly one of `image` or `source` must be specified.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: isValidImageSource,
+		StateFunc: func(v interface{}) string {
+			return sourceSha256(v.(string))
+		},
+	}
+
+	s["optimize"] = &schema.Schema{
+		Description: "Downscale PNG, JPEG and GIF images to the maximum dimensions configured in the provider, and " +
+			"recompress them as JPEG images of decreasing quality until they fit the 1MB limit. Images that already " +
+			"fit are sent as-is. Defaults to `false`.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+
+	s["image_sha256"] = &schema.Schema{
+		Description: "Hex-encoded SHA-256 hash of the image stored in the account.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	return s
+}
+
+// flattenSigningLogoImage sets the hash of the remote image to the `image_sha256` attribute of the logo e.
+// The image and source attributes keep the hash of the configured image from the prior state p while the
+// remote image is the one that was written, which differs from the configured image when it was optimized:
+// either the image was just written by the apply (written), or its hash matches the last one read. Otherwise,
+// the hash is stored in the attribute that the image was given with, `image` if there's no prior state
+// (e.g. on import).
+func flattenSigningLogoImage(e map[string]interface{}, image string, p map[string]interface{}, written bool) {
+	h := imageSha256(image)
+
+	e["image_sha256"] = h
+	e["optimize"] = false
+
+	if p == nil {
+		e["image"] = h
+		return
+	}
+
+	e["optimize"] = p["optimize"]
+
+	k := "image"
+	if p["source"].(string) != "" {
+		k = "source"
+	}
+
+	if v := p[k].(string); v != "" && (written || p["image_sha256"].(string) == h) {
+		e[k] = v
+	} else {
+		e[k] = h
+	}
+}
+
+// isSigningLogoWritten reports whether d is read right after its logos were written by the apply, rather than
+// refreshed or imported. Terraform only sends the configuration when planning and applying.
+func isSigningLogoWritten(d *schema.ResourceData) bool {
+	return !d.GetRawConfig().IsNull()
+}
+
+// signingLogoImage returns the data URI of the image of the logo configuration c, reading it from the
+// `source` file if needed. The image is optimized to fit the limits of the provider m if the logo has
+// `optimize` enabled.
+func signingLogoImage(c cty.Value, m *providerMeta) (string, error) {
+	var img string
+
+	if v := c.GetAttr("image"); !v.IsNull() {
+		img = v.AsString()
+	} else if v := c.GetAttr("source"); !v.IsNull() {
+		var err error
+		if img, err = imageFileDataUri(v.AsString()); err != nil {
+			return "", err
+		}
+	}
+
+	if v := c.GetAttr("optimize"); img == "" || v.IsNull() || v.False() {
+		return img, nil
+	}
+
+	d, err := dataurl.DecodeString(img)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the data URI: %w", err)
+	}
+
+	ct, b, err := optimizeImage(d.ContentType(), d.Data, m.logoMaxWidth, m.logoMaxHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return dataurl.New(b, ct).String(), nil
 }
 
 func isValidImageData(v interface{}, p cty.Path) diag.Diagnostics {
@@ -73,8 +266,29 @@ func isValidImageData(v interface{}, p cty.Path) diag.Diagnostics {
 		})
 	}
 
+	return validateImage(d.ContentType(), d.Data)
+}
+
+func isValidImageSource(v interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ct, b, err := readImageFile(v.(string))
+
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "unable to read the image file",
+			Detail:   err.Error(),
+		})
+	}
+
+	return validateImage(ct, b)
+}
+
+func validateImage(contentType string, data []byte) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	var supportedContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/bmp", "image/svg+xml"}
-	contentType := d.ContentType()
 
 	isValidType := false
 
@@ -93,116 +307,225 @@ func isValidImageData(v interface{}, p cty.Path) diag.Diagnostics {
 		})
 	}
 
-	size := len(d.Data)
+	if isValidType {
+		if err := checkImageContent(contentType, data); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "invalid image content",
+				Detail:   err.Error(),
+			})
+		}
+	}
 
-	if size > 1_000_000 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "content is too large",
-			Detail:   fmt.Sprintf("maximum content size is 1MB, got: %d", size),
+	return diags
+}
+
+// validateAccountSigningLogosDiff validates that the image of each logo is given either as a data URI or as a file,
+// and that the images fit the size limit and the maximum dimensions configured in the provider.
+func validateAccountSigningLogosDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, item := range d.Get("logo").(*schema.Set).List() {
+		i := item.(map[string]interface{})
+
+		if (i["image"].(string) == "") == (i["source"].(string) == "") {
+			return fmt.Errorf("exactly one of `image` or `source` must be specified for the %q logo", i["language"].(string))
+		}
+
+		if l := i["language"].(string); l != "" {
+			checkLanguage(ctx, l, meta.(*providerMeta))
+		}
+	}
+
+	cfg := d.GetRawConfig()
+
+	// Each language has a single logo on the account
+	if logos := cfg.GetAttr("logo"); !logos.IsNull() && logos.IsKnown() {
+		seen := make(map[string]bool)
+
+		for it := logos.ElementIterator(); it.Next(); {
+			_, i := it.Element()
+
+			l := i.GetAttr("language")
+			if !l.IsKnown() || l.IsNull() {
+				continue
+			}
+
+			if seen[l.AsString()] {
+				return fmt.Errorf("the %q logo is specified more than once", l.AsString())
+			}
+
+			seen[l.AsString()] = true
+		}
+	}
+
+	if v := cfg.GetAttr("default_image"); !v.IsNull() {
+		c := cty.ObjectVal(map[string]cty.Value{
+			"image":    v,
+			"source":   cty.NullVal(cty.String),
+			"optimize": cty.NullVal(cty.Bool),
 		})
+
+		if err := validateSigningLogoImage(c, meta.(*providerMeta)); err != nil {
+			return fmt.Errorf("invalid default logo: %w", err)
+		}
 	}
 
-	return diags
+	logos := cfg.GetAttr("logo")
+
+	if logos.IsNull() || !logos.IsWhollyKnown() {
+		return nil
+	}
+
+	for it := logos.ElementIterator(); it.Next(); {
+		_, i := it.Element()
+
+		if err := validateSigningLogoImage(i, meta.(*providerMeta)); err != nil {
+			return fmt.Errorf("invalid %q logo: %w", i.GetAttr("language").AsString(), err)
+		}
+	}
+
+	return nil
 }
 
-func flattenAccountSigningLogos(logos []ossign.SigningLogo) []interface{} {
-	ls := make([]interface{}, len(logos))
+// validateSigningLogoImage checks the size and the dimensions of the image of the logo configuration c, once
+// optimized if the logo has `optimize` enabled, against the limits of the provider m.
+func validateSigningLogoImage(c cty.Value, m *providerMeta) error {
+	if !c.IsWhollyKnown() {
+		return nil
+	}
 
-	for i, v := range logos {
-		e := make(map[string]interface{}, 2)
+	img, err := signingLogoImage(c, m)
+	if err != nil {
+		return err
+	}
 
-		e["language"] = v.Language
-		e["image"] = v.Image
+	d, err := dataurl.DecodeString(img)
+	if img == "" || err != nil {
+		// Reported by the validation of the attributes
+		return nil
+	}
 
-		ls[i] = e
+	if size := len(d.Data); size > maxLogoSize {
+		return fmt.Errorf("maximum content size is 1MB, got: %d, enable `optimize` to recompress the image", size)
 	}
 
-	return ls
+	return checkImageDimensions(d.ContentType(), d.Data, m.logoMaxWidth, m.logoMaxHeight)
 }
 
-func resourceAccountSigningLogosCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
-	var diags diag.Diagnostics
+func setAccountSigningLogosResourceData(d *schema.ResourceData, v interface{}, meta interface{}) error {
+	overridden := make(map[string]bool)
 
-	diags = append(diags, diag.Diagnostic{
-		Severity: diag.Warning,
-		Summary:  "updating (replacing) resource instead of creating",
-		Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
-	})
+	if v, ok := d.Get("logo").(*schema.Set); ok {
+		for _, item := range v.List() {
+			overridden[item.(map[string]interface{})["language"].(string)] = true
+		}
+	}
 
-	diags = append(diags, resourceAccountSigningLogosUpdate(ctx, d, meta)...)
+	dft, _ := d.Get("default_image").(string)
 
-	d.SetId(c.ClientId)
+	ls := supportedLanguages(meta.(*providerMeta))
 
-	return diags
+	dft, logos := foldDefaultSigningLogo(dft, overridden, v.([]ossign.SigningLogo), ls)
+
+	if err := d.Set("default_image", dft); err != nil {
+		return err
+	}
+
+	return d.Set("logo", flattenAccountSigningLogos(d, logos))
 }
 
-func resourceAccountSigningLogosRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
+// foldDefaultSigningLogo removes the logos of the languages that are not overridden and use the default image
+// with the hash h. The hash of the default image is returned if all these languages among ls use it, and an
+// empty string otherwise, so that the default image is written again.
+func foldDefaultSigningLogo(h string, overridden map[string]bool, logos []ossign.SigningLogo, ls []string) (string, []ossign.SigningLogo) {
+	if h == "" {
+		return "", logos
+	}
 
-	var diags diag.Diagnostics
+	folded := make(map[string]bool)
+	rest := make([]ossign.SigningLogo, 0, len(logos))
 
-	logos, err := c.GetAccountSigningLogos()
+	for _, l := range logos {
+		if !overridden[l.Language] && imageSha256(l.Image) == h {
+			folded[l.Language] = true
+			continue
+		}
 
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
+		rest = append(rest, l)
 	}
 
-	if err := d.Set("logo", flattenAccountSigningLogos(logos)); err != nil {
-		return diag.FromErr(err)
+	for _, l := range ls {
+		if !overridden[l] && !folded[l] {
+			return "", rest
+		}
 	}
 
-	return diags
+	return h, rest
 }
 
-func resourceAccountSigningLogosUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
+// flattenAccountSigningLogos flattens the logos with the hash of their image, see flattenSigningLogoImage.
+func flattenAccountSigningLogos(d *schema.ResourceData, logos []ossign.SigningLogo) []interface{} {
+	prior := make(map[string]map[string]interface{})
+
+	if v, ok := d.Get("logo").(*schema.Set); ok {
+		for _, item := range v.List() {
+			i := item.(map[string]interface{})
+			prior[i["language"].(string)] = i
+		}
+	}
+
+	written := isSigningLogoWritten(d)
+	ls := make([]interface{}, len(logos))
+
+	for i, v := range logos {
+		e := make(map[string]interface{}, 5)
+
+		e["language"] = v.Language
+		flattenSigningLogoImage(e, v.Image, prior[v.Language], written)
 
+		ls[i] = e
+	}
+
+	return ls
+}
+
+// buildAccountSigningLogos builds the logos from the configuration, since the state only holds the hash of
+// their image. The default image is used for the supported languages that don't have a logo.
+func buildAccountSigningLogos(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var b []ossign.SigningLogo
 
-	logos := d.Get("logo").(*schema.Set).List()
-	for _, item := range logos {
-		i := item.(map[string]interface{})
+	overridden := make(map[string]bool)
+	cfg := d.GetRawConfig()
 
-		b = append(b, ossign.SigningLogo{
-			Language: i["language"].(string),
-			Image:    i["image"].(string),
-		})
-	}
+	if logos := cfg.GetAttr("logo"); !logos.IsNull() && logos.IsKnown() {
+		for it := logos.ElementIterator(); it.Next(); {
+			_, i := it.Element()
 
-	if err := c.UpdateAccountSigningLogos(b); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
-	}
+			img, err := signingLogoImage(i, meta.(*providerMeta))
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
 
-	// write logs using the tflog package
-	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
-	// for more information
-	tflog.Trace(ctx, "updated the account signing logos resource")
+			l := i.GetAttr("language").AsString()
+			overridden[l] = true
 
-	return resourceAccountSigningLogosRead(ctx, d, meta)
-}
+			b = append(b, ossign.SigningLogo{
+				Language: l,
+				Image:    img,
+			})
+		}
+	}
 
-func resourceAccountSigningLogosDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "no deletion will take place",
-			Detail: `This resource is a singleton. It only supports retrieval or replacement operations.
-			To remove all account signing logos, declare the resource without any "logo" attribute instead.`,
-		},
+	if v := cfg.GetAttr("default_image"); !v.IsNull() {
+		for _, l := range supportedLanguages(meta.(*providerMeta)) {
+			if !overridden[l] {
+				b = append(b, ossign.SigningLogo{
+					Language: l,
+					Image:    v.AsString(),
+				})
+			}
+		}
 	}
+
+	return b, diags
 }
@@ -1,14 +1,22 @@
 package provider
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
 )
 
 const testImg = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAoAAAANCAYAAACQN/8FAAAABGdBTUEAALGPC" +
@@ -29,9 +37,20 @@ const testImg = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAoAAAANCAYAAACQN
 	"AAElFTkSuQmCC"
 
 func TestAccResourceSigningLogos(t *testing.T) {
+
+	d, err := dataurl.DecodeString(testImg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "logo.png")
+	if err := ioutil.WriteFile(src, d.Data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: getTestConfig(fmt.Sprintf(`
@@ -52,12 +71,12 @@ func TestAccResourceSigningLogos(t *testing.T) {
 					resource.TestCheckTypeSetElemNestedAttrs(
 						"onespansign_account_signing_logos.foo", "logo.*", map[string]string{
 							"language": "en",
-							"image":    testImg,
+							"image":    imageSha256(testImg),
 						}),
 					resource.TestCheckTypeSetElemNestedAttrs(
 						"onespansign_account_signing_logos.foo", "logo.*", map[string]string{
 							"language": "fr",
-							"image":    testImg,
+							"image":    imageSha256(testImg),
 						}),
 					testAccCheckSigningLogosResourceMatches([]ossign.SigningLogo{
 						{
@@ -76,16 +95,17 @@ func TestAccResourceSigningLogos(t *testing.T) {
 				resource "onespansign_account_signing_logos" "foo" {
 					logo {
 						language = "en"
-						image = "%s"
+						source = "%s"
 					}
 				}
-				`, testImg)),
+				`, src)),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("onespansign_account_signing_logos.foo", "logo.#", "1"),
 					resource.TestCheckTypeSetElemNestedAttrs(
 						"onespansign_account_signing_logos.foo", "logo.*", map[string]string{
-							"language": "en",
-							"image":    testImg,
+							"language":     "en",
+							"source":       imageSha256(testImg),
+							"image_sha256": imageSha256(testImg),
 						}),
 					testAccCheckSigningLogosResourceMatches([]ossign.SigningLogo{
 						{
@@ -96,9 +116,27 @@ func TestAccResourceSigningLogos(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "onespansign_account_signing_logos.foo",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "onespansign_account_signing_logos.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"logo"},
+			},
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_account_signing_logos" "foo" {
+					default_image = "%s"
+
+					logo {
+						language = "en"
+						source = "%s"
+					}
+				}
+				`, testSvgImg, src)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_account_signing_logos.foo", "default_image", imageSha256(testSvgImg)),
+					resource.TestCheckResourceAttr("onespansign_account_signing_logos.foo", "logo.#", "1"),
+					testAccCheckDefaultSigningLogosMatch(testSvgImg, map[string]string{"en": testImg}),
+				),
 			},
 			{
 				Config: getTestConfig(`resource "onespansign_account_signing_logos" "foo" {}`),
@@ -111,6 +149,62 @@ func TestAccResourceSigningLogos(t *testing.T) {
 	})
 }
 
+const testSvgImg = "data:image/svg+xml;base64,PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciIHdpZHRoPSIxMCIgaGVpZ2h0PSIxMCI+PHJlY3Qgd2lkdGg9IjEwIiBoZWlnaHQ9IjEwIiBmaWxsPSIjMDAwIi8+PC9zdmc+"
+
+func TestFoldDefaultSigningLogo(t *testing.T) {
+	h := imageSha256(testSvgImg)
+
+	ls := []string{"en", "fr", "de"}
+
+	var logos []ossign.SigningLogo
+	for _, l := range ls {
+		logos = append(logos, ossign.SigningLogo{Language: l, Image: testSvgImg})
+	}
+
+	logos[0].Image = testImg
+
+	// The overridden language is kept
+	dft, rest := foldDefaultSigningLogo(h, map[string]bool{logos[0].Language: true}, logos, ls)
+	assert.Equal(t, h, dft)
+	assert.Equal(t, logos[:1], rest)
+
+	// A language doesn't use the default image
+	dft, rest = foldDefaultSigningLogo(h, map[string]bool{}, logos, ls)
+	assert.Equal(t, "", dft)
+	assert.Equal(t, logos[:1], rest)
+
+	// A language is missing
+	dft, rest = foldDefaultSigningLogo(h, map[string]bool{logos[0].Language: true}, logos[:len(logos)-1], ls)
+	assert.Equal(t, "", dft)
+	assert.Equal(t, logos[:1], rest)
+
+	// No default image
+	dft, rest = foldDefaultSigningLogo("", map[string]bool{}, logos, ls)
+	assert.Equal(t, "", dft)
+	assert.Equal(t, logos, rest)
+}
+
+// testAccCheckDefaultSigningLogosMatch checks that the languages supported by OneSpan Sign use the default image dft,
+// except the overridden ones.
+func testAccCheckDefaultSigningLogosMatch(dft string, overrides map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ls := supportedLanguages(newProviderMeta(getTestApiClient()))
+
+		var m []ossign.SigningLogo
+
+		for _, l := range ls {
+			img := dft
+			if v, ok := overrides[l]; ok {
+				img = v
+			}
+
+			m = append(m, ossign.SigningLogo{Language: l, Image: img})
+		}
+
+		return testAccCheckSigningLogosResourceMatches(m)(s)
+	}
+}
+
 func testAccCheckSigningLogosResourceMatches(m []ossign.SigningLogo) resource.TestCheckFunc {
 	return func(*terraform.State) error {
 		c := getTestApiClient()
@@ -140,3 +234,226 @@ func testAccCheckSigningLogosResourceMatches(m []ossign.SigningLogo) resource.Te
 		return nil
 	}
 }
+
+func TestResourceAccountSigningLogosStateUpgradeV0(t *testing.T) {
+	s := map[string]interface{}{
+		"id": "account-id",
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language": "en",
+				"image":    testImg,
+			},
+		},
+	}
+
+	actual, err := upgradeAccountSigningLogosImageToSha256(context.Background(), s, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"id": "account-id",
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language":     "en",
+				"image":        imageSha256(testImg),
+				"image_sha256": imageSha256(testImg),
+				"source":       nil,
+				"optimize":     false,
+			},
+		},
+	}, actual)
+
+	if err := resourceAccountSigningLogosV0().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid version 0 schema: %s", err)
+	}
+}
+
+func TestAccountSigningLogosPlanStable(t *testing.T) {
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+	m.languages = []string{"en", "fr"}
+
+	config := map[string]interface{}{
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language": "en",
+				"image":    testImg,
+			},
+		},
+	}
+
+	p, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logos", nil, config)
+	assert.Empty(t, diags)
+
+	h := imageSha256(testImg)
+
+	logos := p.GetAttr("logo")
+	if assert.Equal(t, 1, logos.LengthInt()) {
+		l := logos.AsValueSlice()[0]
+		assert.Equal(t, h, l.GetAttr("image").AsString())
+	}
+
+	// The state once applied, with the hash of the image written to the account
+	prior := map[string]interface{}{
+		"id":         "account1",
+		"on_destroy": "keep",
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language":     "en",
+				"image":        h,
+				"source":       "",
+				"optimize":     false,
+				"image_sha256": h,
+			},
+		},
+	}
+
+	// Plans are computed by a new provider process
+	m = newProviderMeta(nil)
+	m.accountId = "account1"
+	m.languages = []string{"en", "fr"}
+
+	p2, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logos", prior, config)
+	assert.Empty(t, diags)
+
+	ty := resourceAccountSigningLogos().CoreConfigSchema().ImpliedType()
+	assert.True(t, p2.RawEquals(testCtyValue(ty, prior)), "unexpected plan: %#v", p2)
+}
+
+func TestAccountSigningLogosDuplicateLanguage(t *testing.T) {
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+	m.languages = []string{"en", "fr"}
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logos", nil, map[string]interface{}{
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language": "en",
+				"image":    testImg,
+			},
+			map[string]interface{}{
+				"language": "en",
+				"image":    testSvgImg,
+			},
+		},
+	})
+
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Summary, `the "en" logo is specified more than once`)
+	}
+}
+
+// testOptimizedLogoImages returns two images that are downscaled by the optimization with the provider m, along
+// with their optimized version.
+func testOptimizedLogoImages(t *testing.T, m *providerMeta) (string, string, string, string) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, noiseImage(20, 20)); err != nil {
+		t.Fatal(err)
+	}
+
+	img1 := testImg
+	img2 := dataurl.New(buf.Bytes(), "image/png").String()
+
+	optimized := func(img string) string {
+		o, err := signingLogoImage(cty.ObjectVal(map[string]cty.Value{
+			"image":    cty.StringVal(img),
+			"source":   cty.NullVal(cty.String),
+			"optimize": cty.True,
+		}), m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if o == img {
+			t.Fatal("the image was not optimized")
+		}
+
+		return o
+	}
+
+	return img1, optimized(img1), img2, optimized(img2)
+}
+
+func TestAccountSigningLogosOptimizeUpdate(t *testing.T) {
+	res := map[string]interface{}{}
+	m := newProviderMeta(testApiClient(t, res))
+	m.accountId = "account1"
+	m.languages = []string{"en"}
+	m.logoMaxWidth = 5
+	m.logoMaxHeight = 5
+
+	img1, opt1, img2, opt2 := testOptimizedLogoImages(t, m)
+
+	res["/api/account/admin/signingLogos"] = []interface{}{
+		map[string]interface{}{"language": "en", "image": opt1},
+	}
+
+	state := func(img string, opt string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":             "account1",
+			"on_destroy":     "keep",
+			"original_value": "",
+			"default_image":  "",
+			"logo": []interface{}{
+				map[string]interface{}{
+					"language":     "en",
+					"image":        imageSha256(img),
+					"source":       "",
+					"optimize":     true,
+					"image_sha256": imageSha256(opt),
+				},
+			},
+		}
+	}
+
+	config := map[string]interface{}{
+		"logo": []interface{}{
+			map[string]interface{}{
+				"language": "en",
+				"image":    img2,
+				"optimize": true,
+			},
+		},
+	}
+
+	prior := state(img1, opt1)
+
+	p, diags := testPlanResourceChange(t, m, "onespansign_account_signing_logos", prior, config)
+	assert.Empty(t, diags)
+
+	s, diags := testApplyResourceChange(t, m, "onespansign_account_signing_logos", prior, p, config)
+	assert.Empty(t, diags)
+
+	// The configured image is kept while the written image is the optimized one
+	ty := resourceAccountSigningLogos().CoreConfigSchema().ImpliedType()
+	expected := testCtyValue(ty, state(img2, opt2))
+	assert.True(t, s.RawEquals(expected), "unexpected state: %#v", s)
+
+	// Plans are computed by a new provider process
+	m2 := newProviderMeta(m.client)
+	m2.accountId = "account1"
+	m2.languages = []string{"en"}
+	m2.logoMaxWidth = 5
+	m2.logoMaxHeight = 5
+
+	p2, diags := testPlanResourceChange(t, m2, "onespansign_account_signing_logos", state(img2, opt2), config)
+	assert.Empty(t, diags)
+	assert.True(t, p2.RawEquals(expected), "unexpected plan: %#v", p2)
+}
+
+func TestHashSigningLogo(t *testing.T) {
+	h := imageSha256(testImg)
+
+	state := map[string]interface{}{"language": "en", "image": h, "source": "", "optimize": false, "image_sha256": "remote"}
+	config := map[string]interface{}{"language": "en", "image": testImg, "source": "", "optimize": false}
+
+	assert.Equal(t, hashSigningLogo(state), hashSigningLogo(config))
+
+	config["image"] = testSvgImg
+	assert.NotEqual(t, hashSigningLogo(state), hashSigningLogo(config))
+
+	config["image"] = testImg
+	config["optimize"] = true
+	assert.NotEqual(t, hashSigningLogo(state), hashSigningLogo(config))
+}
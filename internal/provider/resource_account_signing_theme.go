@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// signingThemesLock is the name of the provider lock serializing the writes to the account's signing themes.
+const signingThemesLock = "signing_themes"
+
+func resourceAccountSigningTheme() *schema.Resource {
+	s := signingThemeAccessibilitySchema(signingThemeSchema())
+	s["name"].ForceNew = true
+
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Named signing theme of the OneSpan Sign account.\n\n" +
+			"Only the theme of its name is managed by the resource, so that several themes (e.g. seasonal or brand variants) " +
+			"can be staged in the account. New themes are added after the existing ones, the theme used by the Signing Ceremony " +
+			"is selected with `onespansign_account_active_signing_theme`. It should not be used together with " +
+			"`onespansign_account_signing_themes`, which manages all the themes of the account.",
+
+		CreateContext: resourceAccountSigningThemeCreate,
+		ReadContext:   resourceAccountSigningThemeRead,
+		UpdateContext: resourceAccountSigningThemeUpdate,
+		DeleteContext: resourceAccountSigningThemeDelete,
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			i := make(map[string]interface{}, len(themeColorTexts)+1)
+			i["name"] = d.Get("name")
+
+			for k := range themeColorTexts {
+				// Unknown colors are skipped by the checks
+				if d.NewValueKnown(k) {
+					i[k] = d.Get(k)
+				}
+			}
+
+			return validateSigningThemeAccessibility(ctx, i, d.Get("accessibility_level").(string), d.Get("accessibility_severity").(string), d.Get("check_color_vision").(bool))
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAccountSigningThemeImport,
+		},
+
+		Schema: s,
+	}
+}
+
+// updateSigningThemes applies f to the account's signing themes, writes the result and waits for the API to reflect
+// it. The themes are deleted from the account when f returns no themes.
+func updateSigningThemes(ctx context.Context, m *providerMeta, f func(ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics)) diag.Diagnostics {
+	unlock := m.lock(signingThemesLock)
+	defer unlock()
+
+	ts, apiErr := m.client.GetAccountSigningThemes()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	b, diags := f(ts)
+	if diags.HasError() {
+		return diags
+	}
+
+	switch {
+	case len(b) == 0:
+		apiErr = m.client.DeleteAccountSigningThemes()
+	case len(ts) == 0:
+		apiErr = m.client.CreateAccountSigningThemes(b)
+	default:
+		apiErr = m.client.UpdateAccountSigningThemes(b)
+	}
+
+	if apiErr != nil {
+		return append(diags, apiErrorDiags(apiErr)...)
+	}
+
+	// The lock is held until the API reflects the change, so that the next writes don't read stale themes
+	tflog.Trace(ctx, "waiting for the account's signing themes to be updated...")
+
+	scc := getSigningThemeStateChangeConf(m.client, b)
+	if _, err := scc.WaitForStateContext(ctx); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// getSigningThemeResourceData builds the theme item of the resource data, as in the `theme` block of
+// `onespansign_account_signing_themes`.
+func getSigningThemeResourceData(d *schema.ResourceData) map[string]interface{} {
+	i := make(map[string]interface{}, len(themeColorTexts)+2)
+	i["name"] = d.Get("name")
+	i["sections"] = d.Get("sections")
+
+	for k := range themeColorTexts {
+		i[k] = d.Get(k)
+	}
+
+	return i
+}
+
+func resourceAccountSigningThemeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	n := d.Get("name").(string)
+
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	i := getSigningThemeResourceData(d)
+
+	diags := updateSigningThemes(ctx, m, func(ts ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics) {
+		if _, ok := ts.Get(n); ok {
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("the %q signing theme already exists", n),
+					Detail:   fmt.Sprintf("The account already has a signing theme named %q. Import it with `terraform import` to manage it.", n),
+				},
+			}
+		}
+
+		return append(append(ossign.SigningThemes{}, ts...), ossign.NamedSigningTheme{Name: n, Theme: expandSigningTheme(i)}), nil
+	})
+
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", id, n))
+
+	return append(diags, resourceAccountSigningThemeRead(ctx, d, meta)...)
+}
+
+func resourceAccountSigningThemeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	n := d.Get("name").(string)
+
+	ts, apiErr := meta.(*providerMeta).client.GetAccountSigningThemes()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	t, ok := ts.Get(n)
+	if !ok {
+		tflog.Warn(ctx, "the signing theme was removed outside of Terraform, removing it from the state", map[string]interface{}{
+			"name": n,
+		})
+		d.SetId("")
+		return nil
+	}
+
+	for k, v := range flattenAccountSigningTheme(n, t).(map[string]interface{}) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAccountSigningThemeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	n := d.Get("name").(string)
+	i := getSigningThemeResourceData(d)
+	keepSections := d.GetRawConfig().GetAttr("sections").IsNull()
+
+	diags := updateSigningThemes(ctx, meta.(*providerMeta), func(ts ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics) {
+		b := make(ossign.SigningThemes, 0, len(ts)+1)
+		found := false
+
+		// The theme keeps its position, so that the active theme doesn't change
+		for _, v := range ts {
+			if v.Name == n {
+				t := expandSigningTheme(i)
+
+				// The sections that are not configured are kept untouched
+				if keepSections {
+					t.Sections = v.Theme.Sections
+				}
+
+				v.Theme = t
+				found = true
+			}
+
+			b = append(b, v)
+		}
+
+		if !found {
+			b = append(b, ossign.NamedSigningTheme{Name: n, Theme: expandSigningTheme(i)})
+		}
+
+		return b, nil
+	})
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAccountSigningThemeRead(ctx, d, meta)...)
+}
+
+func resourceAccountSigningThemeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	n := d.Get("name").(string)
+
+	return updateSigningThemes(ctx, meta.(*providerMeta), func(ts ossign.SigningThemes) (ossign.SigningThemes, diag.Diagnostics) {
+		b := make(ossign.SigningThemes, 0, len(ts))
+
+		for _, v := range ts {
+			if v.Name != n {
+				b = append(b, v)
+			}
+		}
+
+		return b, nil
+	})
+}
+
+// resourceAccountSigningThemeImport imports a named theme. The import ID is the name of the theme, optionally
+// prefixed by the account ID or the "account" alias (e.g. "account/default").
+func resourceAccountSigningThemeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, apiErr := meta.(*providerMeta).getAccountId()
+	if apiErr != nil {
+		return nil, apiErr.GetError()
+	}
+
+	n := d.Id()
+
+	if i := strings.Index(n, "/"); i >= 0 {
+		if a := n[:i]; a == id || a == importSingletonIdAlias {
+			n = n[i+1:]
+		}
+	}
+
+	if err := d.Set("name", n); err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", id, n))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAccSigningThemeConfig(n string, th ossign.SigningTheme) string {
+	return fmt.Sprintf(`
+	resource "onespansign_account_signing_theme" "%s" {
+		name = "%s"
+		primary = "%s"
+		success = "%s"
+		warning = "%s"
+		error = "%s"
+		info = "%s"
+		signature_button = "%s"
+		optional_signature_button = "%s"
+	}
+	`, n, n, th.Primary, th.Success, th.Warning, th.Error, th.Info, th.SignatureButton, th.OptionalSignatureButton)
+}
+
+func TestAccResourceSigningTheme(t *testing.T) {
+	th := generateSigningTheme()
+	th2 := generateSigningTheme()
+	th3 := generateSigningTheme()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSigningThemesResourceDestroyed,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccSigningThemesPreTestCleanup,
+				Config:    getTestConfig(testAccSigningThemeConfig("summer", th) + testAccSigningThemeConfig("winter", th2)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_account_signing_theme.summer", "primary", th.Primary),
+					resource.TestCheckResourceAttr("onespansign_account_signing_theme.winter", "primary", th2.Primary),
+					testAccCheckSigningThemeExists("summer", th),
+					testAccCheckSigningThemeExists("winter", th2),
+				),
+			},
+			{
+				ResourceName:      "onespansign_account_signing_theme.winter",
+				ImportState:       true,
+				ImportStateId:     "account/winter",
+				ImportStateVerify: true,
+			},
+			{
+				Config: getTestConfig(testAccSigningThemeConfig("summer", th) + testAccSigningThemeConfig("winter", th3) + `
+				resource "onespansign_account_active_signing_theme" "foo" {
+					name = onespansign_account_signing_theme.winter.name
+				}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_account_active_signing_theme.foo", "name", "winter"),
+					testAccCheckSigningThemeExists("summer", th),
+					testAccCheckSigningThemeExists("winter", th3),
+					testAccCheckActiveSigningTheme("winter"),
+				),
+			},
+			{
+				ResourceName:      "onespansign_account_active_signing_theme.foo",
+				ImportState:       true,
+				ImportStateId:     "account",
+				ImportStateVerify: true,
+			},
+			{
+				Config: getTestConfig(testAccSigningThemeConfig("summer", th) + testAccSigningThemeConfig("winter", th3) + `
+				resource "onespansign_account_active_signing_theme" "foo" {
+					name = "autumn"
+				}
+				`),
+				ExpectError: regexp.MustCompile(`the "autumn" signing theme does not exist`),
+			},
+			{
+				Config: getTestConfig(testAccSigningThemeConfig("summer", th)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSigningThemeExists("summer", th),
+					testAccCheckSigningThemeDestroyed("winter"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSigningThemeExists(n string, e ossign.SigningTheme) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ts, apiErr := getTestApiClient().GetAccountSigningThemes()
+		if apiErr != nil {
+			return apiErr.GetError()
+		}
+
+		if t, ok := ts.Get(n); !ok || !t.Equal(e) {
+			return fmt.Errorf("Signing theme %q does not match expectation", n)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSigningThemeDestroyed(n string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ts, apiErr := getTestApiClient().GetAccountSigningThemes()
+		if apiErr != nil {
+			return apiErr.GetError()
+		}
+
+		if _, ok := ts.Get(n); ok {
+			return fmt.Errorf("Signing theme %q still exists", n)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckActiveSigningTheme(n string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ts, apiErr := getTestApiClient().GetAccountSigningThemes()
+		if apiErr != nil {
+			return apiErr.GetError()
+		}
+
+		if len(ts) == 0 || ts[0].Name != n {
+			return fmt.Errorf("Signing theme %q is not the active theme, got %q", n, ts.Names())
+		}
+
+		return nil
+	}
+}
+
+func TestCheckActiveSigningTheme(t *testing.T) {
+	ts := ossign.SigningThemes{
+		{Name: "spring"},
+		{Name: "autumn"},
+	}
+
+	assert.Empty(t, checkActiveSigningTheme(ts, "spring"))
+
+	diags := checkActiveSigningTheme(ts, "autumn")
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, `the order ["spring" "autumn"]`)
+	}
+
+	assert.Len(t, checkActiveSigningTheme(ossign.SigningThemes{}, "autumn"), 1)
+}
+
+func TestOrderSigningThemes(t *testing.T) {
+	ts := ossign.SigningThemes{
+		{Name: "spring"},
+		{Name: "summer"},
+		{Name: "autumn"},
+	}
+
+	assert.Equal(t, []string{"autumn", "spring", "summer"}, orderSigningThemes(ts, []string{"autumn"}).Names())
+	assert.Equal(t, []string{"summer", "spring", "autumn"}, orderSigningThemes(ts, []string{"summer", "winter", "spring"}).Names())
+
+	// The original order is restored, the themes added in the meantime come last
+	o := ts.Names()
+	ts = append(orderSigningThemes(ts, []string{"autumn"}), ossign.NamedSigningTheme{Name: "winter"})
+	assert.Equal(t, []string{"spring", "summer", "autumn", "winter"}, orderSigningThemes(ts, o).Names())
+}
+
+func TestActiveSigningThemeDuplicate(t *testing.T) {
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_active_signing_theme", nil, map[string]interface{}{"name": "spring"})
+	assert.Empty(t, diags)
+
+	_, diags = testPlanResourceChange(t, m, "onespansign_account_active_signing_theme", nil, map[string]interface{}{"name": "autumn"})
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Summary, `planned earlier in the run (a new instance configuring "name")`)
+	}
+}
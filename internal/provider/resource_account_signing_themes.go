@@ -2,312 +2,482 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
-	"regexp"
+	"strings"
 	"time"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceAccountSigningThemes() *schema.Resource {
-	return &schema.Resource{
-		Description: `OneSpan Sign account's customized signing themes.
-		
-Please note that this resource is a singleton, which means that only one instance of this resource should
-exist. Having multiple instances may produce unexpected result.`,
+	r := accountSigningThemesSingleton()
 
-		CreateContext: resourceAccountSigningThemesCreate,
-		ReadContext:   resourceAccountSigningThemesRead,
-		UpdateContext: resourceAccountSigningThemesUpdate,
-		DeleteContext: resourceAccountSigningThemesDelete,
+	// The accessibility checks only apply to the plan, they are not part of the data source
+	signingThemeAccessibilitySchema(r.Schema)
+
+	r.CustomizeDiff = validateAccountSigningThemesDiff
+
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceAccountSigningThemesV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeSetBlockToList,
+		},
+	}
+
+	return r.Resource()
+}
+
+// resourceAccountSigningThemesV0 is the version 0 of the resource, where the `theme` block was a set.
+func resourceAccountSigningThemesV0() *schema.Resource {
+	r := accountSigningThemesSingleton().Resource()
+	r.Schema["theme"].Type = schema.TypeSet
+
+	return r
+}
+
+func accountSigningThemesSingleton() *singletonResource {
+	return &singletonResource{
+		TypeName:    "onespansign_account_signing_themes",
+		Name:        "signing themes",
+		Description: "OneSpan Sign account's customized signing themes.",
+
+		Get: func(c *ossign.ApiClient) (interface{}, *ossign.ApiError) {
+			return c.GetAccountSigningThemes()
+		},
+		Set: func(c *ossign.ApiClient, v interface{}) *ossign.ApiError {
+			return c.UpdateAccountSigningThemes(v.(ossign.SigningThemes))
+		},
+		Create: func(c *ossign.ApiClient, v interface{}) *ossign.ApiError {
+			return c.CreateAccountSigningThemes(v.(ossign.SigningThemes))
+		},
+		Delete: func(c *ossign.ApiClient) *ossign.ApiError {
+			return c.DeleteAccountSigningThemes()
+		},
+		Default: ossign.SigningThemes{},
+		Exists: func(v interface{}) bool {
+			return len(v.(ossign.SigningThemes)) > 0
+		},
+		Flatten: setResourceData,
+		Expand: func(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, diag.Diagnostics) {
+			return buildAccountSigningThemes(d, meta)
+		},
+		Waiter: func(c *ossign.ApiClient, e interface{}) resource.StateChangeConf {
+			return getSigningThemeStateChangeConf(c, e.(ossign.SigningThemes))
+		},
 
 		Schema: map[string]*schema.Schema{
 			"theme": {
 				Description: "Customized signing theme for the account.",
-				Type:        schema.TypeSet,
+				Type:        schema.TypeList,
 				Required:    true,
 
-				// The current API behaviour is that we are able to create multiple themes for the account.
-				// However, only the first theme will be used for the signing ceremony.
-				// This constraint is added so that the user does not expect to have multiple themes.
+				// The API accepts several themes for the account, which are managed by `onespansign_account_signing_theme`
+				// instead. The themes written by this resource replace all the themes of the account, the themes added
+				// outside of Terraform are kept in the state so that the plan shows their removal.
 				MaxItems: 1,
 
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "Name of the theme.",
-							Required:    true,
-							Type:        schema.TypeString,
-						},
-						"primary": {
-							Description:      "Primary color hex code.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"success": {
-							Description:      "Success notification color hex code.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"warning": {
-							Description:      "Warning notification color hex code.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"error": {
-							Description:      "Error notification color hex code.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"info": {
-							Description:      "Info notification color hex code.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"signature_button": {
-							Description:      "Color hex code for the required signature buttons.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-						"optional_signature_button": {
-							Description:      "Color hex code for the optional signature buttons.",
-							Required:         true,
-							Type:             schema.TypeString,
-							ValidateDiagFunc: validateColorHex,
-						},
-					},
+					Schema: signingThemeSchema(),
 				},
 			},
 		},
+	}
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+// signingThemeAccessibilitySchema adds the attributes configuring the accessibility checks of the theme colors
+// to the schema s.
+func signingThemeAccessibilitySchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["accessibility_level"] = &schema.Schema{
+		Description: fmt.Sprintf("WCAG 2.1 level that the contrast of the theme colors with the ceremony's text must meet: `%s`, `%s` or `%s`. "+
+			"The ceremony displays white labels over the theme colors, except black labels over the `warning` color, so each color "+
+			"is checked against the text displayed over it. The contrast with the other text is reported for reference. Defaults to `%s`.",
+			accessibilityLevelOff, accessibilityLevelAA, accessibilityLevelAAA, accessibilityLevelOff),
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{accessibilityLevelOff, accessibilityLevelAA, accessibilityLevelAAA}, false)),
+	}
+	s["accessibility_severity"] = &schema.Schema{
+		Description: fmt.Sprintf("How the colors that don't meet `accessibility_level` are reported during the plan: `%s` fails the plan and "+
+			"`%s` only warns. Defaults to `%s`.", accessibilitySeverityError, accessibilitySeverityWarning, accessibilitySeverityError),
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{accessibilitySeverityError, accessibilitySeverityWarning}, false)),
+	}
+	s["check_color_vision"] = &schema.Schema{
+		Description: "Whether to report the notification colors, and the signature button colors, that are hard to tell apart with " +
+			"protanopia, deuteranopia or tritanopia. They are reported as warnings during the plan.",
+		Type:     schema.TypeBool,
+		Optional: true,
 	}
+
+	return s
 }
 
-func validateColorHex(v interface{}, p cty.Path) diag.Diagnostics {
-	r := regexp.MustCompile("^#[0-9A-F]{6}$")
-	return validation.ToDiagFunc(validation.StringMatch(r, "must be a valid 6-symbol color hex code (e.g. #FFFFFF)"))(v, p)
+// signingThemeSchema returns the schema of the name and colors of a signing theme.
+func signingThemeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the theme.",
+			Required:    true,
+			Type:        schema.TypeString,
+		},
+		"sections": {
+			Description: "Other sections of the theme supported by the API (e.g. fonts, text colors or background), keyed by name, as JSON objects. " +
+				"When not configured, the remote sections are kept untouched.",
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Computed:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateDiagFunc: validateSigningThemeSections,
+			DiffSuppressFunc: structure.SuppressJsonDiff,
+		},
+		"primary": {
+			Description:      "Primary color, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"success": {
+			Description:      "Success notification color, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"warning": {
+			Description:      "Warning notification color, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"error": {
+			Description:      "Error notification color, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"info": {
+			Description:      "Info notification color, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"signature_button": {
+			Description:      "Color of the required signature buttons, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+		"optional_signature_button": {
+			Description:      "Color of the optional signature buttons, as a hex code or a CSS named color.",
+			Required:         true,
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateColor,
+			DiffSuppressFunc: suppressEquivalentColorDiff,
+		},
+	}
 }
 
 func flattenAccountSigningTheme(name string, t ossign.SigningTheme) interface{} {
-	ft := make(map[string]interface{}, 8)
+	ft := make(map[string]interface{}, 9)
+
+	sections := make(map[string]interface{}, len(t.Sections))
+	for k, v := range t.Sections {
+		n, err := structure.NormalizeJsonString(string(v))
+		if err != nil {
+			n = string(v)
+		}
+		sections[k] = n
+	}
 
 	ft["name"] = name
-	ft["primary"] = t.Primary
-	ft["success"] = t.Success
-	ft["warning"] = t.Warning
-	ft["error"] = t.Error
-	ft["info"] = t.Info
-	ft["signature_button"] = t.SignatureButton
-	ft["optional_signature_button"] = t.OptionalSignatureButton
+	ft["primary"] = normalizeColorOrKeep(t.Primary)
+	ft["success"] = normalizeColorOrKeep(t.Success)
+	ft["warning"] = normalizeColorOrKeep(t.Warning)
+	ft["error"] = normalizeColorOrKeep(t.Error)
+	ft["info"] = normalizeColorOrKeep(t.Info)
+	ft["signature_button"] = normalizeColorOrKeep(t.SignatureButton)
+	ft["optional_signature_button"] = normalizeColorOrKeep(t.OptionalSignatureButton)
+	ft["sections"] = sections
 
 	return ft
 }
 
-func buildAccountSigningThemes(d *schema.ResourceData) map[string]ossign.SigningTheme {
-	ts := d.Get("theme").(*schema.Set).List()
+// buildAccountSigningThemes builds the themes to write from the resource data. The themes whose sections are not
+// configured keep the sections of the remote theme of the same name.
+func buildAccountSigningThemes(d *schema.ResourceData, meta interface{}) (ossign.SigningThemes, diag.Diagnostics) {
+	ts := d.Get("theme").([]interface{})
+	cfg := d.GetRawConfig().GetAttr("theme")
+
+	r := make(ossign.SigningThemes, 0, len(ts))
 
-	r := make(map[string]ossign.SigningTheme, len(ts))
+	var remote ossign.SigningThemes
 
-	for _, item := range ts {
+	for n, item := range ts {
 		i := item.(map[string]interface{})
+		t := expandSigningTheme(i)
+
+		if !signingThemeSectionsConfigured(cfg, n) {
+			if remote == nil {
+				var apiErr *ossign.ApiError
+
+				remote, apiErr = meta.(*providerMeta).client.GetAccountSigningThemes()
+				if apiErr != nil {
+					return nil, apiErrorDiags(apiErr)
+				}
+			}
 
-		r[i["name"].(string)] = ossign.SigningTheme{
-			Primary:                 i["primary"].(string),
-			Success:                 i["success"].(string),
-			Warning:                 i["warning"].(string),
-			Error:                   i["error"].(string),
-			Info:                    i["info"].(string),
-			SignatureButton:         i["signature_button"].(string),
-			OptionalSignatureButton: i["optional_signature_button"].(string),
+			rt, _ := remote.Get(i["name"].(string))
+			t.Sections = rt.Sections
 		}
+
+		r = append(r, ossign.NamedSigningTheme{
+			Name:  i["name"].(string),
+			Theme: t,
+		})
 	}
 
-	return r
+	return r, nil
 }
 
-// getSigningThemeStateChangeConf gets the configuration struct for the `WaitForState` functions.
-// c is the OneSpan Sign API client instance, whereas e is the expected map of signing themes state.
-func getSigningThemeStateChangeConf(c *ossign.ApiClient, e map[string]ossign.SigningTheme) resource.StateChangeConf {
-	return resource.StateChangeConf{
-		Delay:                     30 * time.Second,
-		Pending:                   []string{"waiting"},
-		Target:                    []string{"complete"},
-		Timeout:                   5 * time.Minute,
-		MinTimeout:                300 * time.Millisecond,
-		ContinuousTargetOccurence: 8,
-		Refresh: func() (result interface{}, state string, err error) {
-			t, apiErr := c.GetAccountSigningThemes()
-
-			if apiErr != nil {
-				if apiErr.HttpResponse != nil && apiErr.HttpResponse.StatusCode == http.StatusInternalServerError {
-					// This API somtimes return transient 500 errors, we want to continue waiting when that happens
-					return t, "waiting", nil
-				}
-				return nil, "error", apiErr.GetError()
-			}
-
-			if len(e) != len(t) {
-				return t, "waiting", nil
-			}
+// signingThemeSectionsConfigured reports whether the sections of the n-th item of the theme list configuration
+// cfg are configured.
+func signingThemeSectionsConfigured(cfg cty.Value, n int) bool {
+	if cfg.IsNull() || !cfg.IsKnown() || cfg.LengthInt() <= n {
+		return false
+	}
 
-			eq := true
+	return !cfg.Index(cty.NumberIntVal(int64(n))).GetAttr("sections").IsNull()
+}
 
-			for k1, v1 := range e {
-				v2 := t[k1]
+// expandSigningTheme builds the colors and sections of the theme item i. Colors are sent in their canonical form,
+// they are validated by the schema.
+func expandSigningTheme(i map[string]interface{}) ossign.SigningTheme {
+	var sections map[string]json.RawMessage
 
-				if !v1.Equal(v2) {
-					eq = false
-					break
-				}
-			}
+	if s, ok := i["sections"].(map[string]interface{}); ok && len(s) > 0 {
+		sections = make(map[string]json.RawMessage, len(s))
 
-			if !eq {
-				return t, "waiting", nil
-			}
+		for k, v := range s {
+			sections[k] = json.RawMessage(v.(string))
+		}
+	}
 
-			return t, "complete", nil
-		},
+	return ossign.SigningTheme{
+		Sections:                sections,
+		Primary:                 normalizeColorOrKeep(i["primary"].(string)),
+		Success:                 normalizeColorOrKeep(i["success"].(string)),
+		Warning:                 normalizeColorOrKeep(i["warning"].(string)),
+		Error:                   normalizeColorOrKeep(i["error"].(string)),
+		Info:                    normalizeColorOrKeep(i["info"].(string)),
+		SignatureButton:         normalizeColorOrKeep(i["signature_button"].(string)),
+		OptionalSignatureButton: normalizeColorOrKeep(i["optional_signature_button"].(string)),
 	}
 }
 
-func setResourceData(d *schema.ResourceData, ts map[string]ossign.SigningTheme) diag.Diagnostics {
+func validateSigningThemeSections(v interface{}, p cty.Path) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	for k, v := range ts {
-		// Only pick the first element that'll be used as the signing theme
-		if err := d.Set("theme", []interface{}{flattenAccountSigningTheme(k, v)}); err != nil {
-			return diag.FromErr(err)
+	for k, s := range v.(map[string]interface{}) {
+		var o map[string]interface{}
+
+		if k == "color" {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid theme section",
+				Detail:        "The colors of the theme are configured with their own attributes, not as a section.",
+				AttributePath: append(p, cty.IndexStep{Key: cty.StringVal(k)}),
+			})
+		} else if err := json.Unmarshal([]byte(s.(string)), &o); err != nil || o == nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid theme section",
+				Detail:        fmt.Sprintf("The %q section of the theme must be a JSON object.", k),
+				AttributePath: append(p, cty.IndexStep{Key: cty.StringVal(k)}),
+			})
 		}
-		break
 	}
 
 	return diags
 }
 
-func resourceAccountSigningThemesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
+// themeColors returns the colors of the theme item i, keyed by attribute name.
+func themeColors(i map[string]interface{}) map[string]string {
+	r := make(map[string]string, len(themeColorTexts))
 
-	var diags diag.Diagnostics
+	for k := range themeColorTexts {
+		if c, ok := i[k].(string); ok {
+			r[k] = c
+		}
+	}
 
-	b := buildAccountSigningThemes(d)
+	return r
+}
 
-	if err := c.CreateAccountSigningThemes(b); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
+// validateAccountSigningThemesDiff checks that the planned theme colors meet the configured accessibility level.
+func validateAccountSigningThemesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("theme") {
+		return nil
 	}
 
-	tflog.Trace(ctx, "waiting for the signing theme resource to be created...")
+	// The account has themes that are not managed by the resource, they are replaced by the configured theme
+	if o, n := d.GetChange("theme"); len(o.([]interface{})) > 1 {
+		if removed := removedSigningThemes(o.([]interface{}), n.([]interface{})); len(removed) > 0 {
+			addPlanWarning(ctx, "Signing themes removed from the account",
+				fmt.Sprintf("The account has several signing themes. This resource manages a single theme and replaces all "+
+					"the themes of the account, the following themes will be removed: %s. Use `onespansign_account_signing_theme` "+
+					"to manage several themes.", strings.Join(removed, ", ")))
+		}
+	}
+
+	level, _ := d.Get("accessibility_level").(string)
+	severity, _ := d.Get("accessibility_severity").(string)
+	cv, _ := d.Get("check_color_vision").(bool)
 
-	scc := getSigningThemeStateChangeConf(c, b)
-	_, err := scc.WaitForStateContext(ctx)
+	for _, item := range d.Get("theme").([]interface{}) {
+		i, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
 
-	if err != nil {
-		return diag.FromErr(err)
+		if err := validateSigningThemeAccessibility(ctx, i, level, severity, cv); err != nil {
+			return err
+		}
 	}
 
-	tflog.Trace(ctx, "created the account's signing theme resource")
+	return nil
+}
 
-	d.SetId(c.ClientId)
+// removedSigningThemes returns the quoted names of the theme items of o that are not in n.
+func removedSigningThemes(o []interface{}, n []interface{}) []string {
+	kept := make(map[string]bool, len(n))
+	for _, item := range n {
+		if i, ok := item.(map[string]interface{}); ok {
+			kept[i["name"].(string)] = true
+		}
+	}
 
-	return resourceAccountSigningThemesRead(ctx, d, meta)
+	var r []string
+	for _, item := range o {
+		if i, ok := item.(map[string]interface{}); ok && !kept[i["name"].(string)] {
+			r = append(r, fmt.Sprintf("%q", i["name"]))
+		}
+	}
+
+	return r
 }
 
-func resourceAccountSigningThemesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
+// validateSigningThemeAccessibility checks that the colors of the theme item i meet the accessibility level. The
+// colors that don't are reported as an error, or as a plan warning when severity is `warning`. The colors that are
+// hard to tell apart are reported as plan warnings when cv is true.
+func validateSigningThemeAccessibility(ctx context.Context, i map[string]interface{}, level string, severity string, cv bool) error {
+	t := themeColors(i)
 
-	var diags diag.Diagnostics
+	if v := checkThemeContrast(t, level); len(v) > 0 {
+		summary := fmt.Sprintf("the colors of the %q theme don't meet the WCAG %s accessibility level", i["name"], level)
+		detail := fmt.Sprintf("  - %s", strings.Join(v, "\n  - "))
 
-	ts, apiErr := c.GetAccountSigningThemes()
+		if severity != accessibilitySeverityWarning {
+			return fmt.Errorf("%s:\n%s", summary, detail)
+		}
 
-	if apiErr != nil {
-		return diag.FromErr(apiErr.GetError())
+		addPlanWarning(ctx, summary, fmt.Sprintf("The following colors don't have enough contrast:\n%s", detail))
 	}
 
-	if len(ts) < 1 {
-		d.SetId("")
-		return diags
+	if !cv {
+		return nil
 	}
 
-	diags = append(diags, setResourceData(d, ts)...)
+	if v := checkThemeColorVision(t); len(v) > 0 {
+		addPlanWarning(ctx, fmt.Sprintf("the colors of the %q theme are hard to tell apart with a color vision deficiency", i["name"]),
+			fmt.Sprintf("The following colors may be confused by some signers:\n  - %s", strings.Join(v, "\n  - ")))
+	}
 
-	return diags
+	return nil
 }
 
-func resourceAccountSigningThemesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
-
-	var diags diag.Diagnostics
+// getSigningThemeStateChangeConf gets the configuration struct for the `WaitForState` functions.
+// c is the OneSpan Sign API client instance, whereas e is the expected signing themes state.
+func getSigningThemeStateChangeConf(c *ossign.ApiClient, e ossign.SigningThemes) resource.StateChangeConf {
+	return resource.StateChangeConf{
+		Delay:                     30 * time.Second,
+		Pending:                   []string{"waiting"},
+		Target:                    []string{"complete"},
+		Timeout:                   5 * time.Minute,
+		MinTimeout:                300 * time.Millisecond,
+		ContinuousTargetOccurence: 8,
+		Refresh: func() (result interface{}, state string, err error) {
+			t, apiErr := c.GetAccountSigningThemes()
 
-	b := buildAccountSigningThemes(d)
+			if apiErr != nil {
+				if apiErr.HttpResponse != nil && apiErr.HttpResponse.StatusCode == http.StatusInternalServerError {
+					// This API somtimes return transient 500 errors, we want to continue waiting when that happens
+					return t, "waiting", nil
+				}
+				return nil, "error", apiErr.GetError()
+			}
 
-	if err := c.UpdateAccountSigningThemes(b); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
-	}
+			if len(e) != len(t) {
+				return t, "waiting", nil
+			}
 
-	tflog.Trace(ctx, "waiting for the signing theme resource to be updated...")
+			// The API doesn't necessarily return the themes in the order they were written
+			eq := true
 
-	scc := getSigningThemeStateChangeConf(c, b)
-	_, err := scc.WaitForStateContext(ctx)
+			for _, v1 := range e {
+				v2, ok := t.Get(v1.Name)
 
-	if err != nil {
-		return diag.FromErr(err)
-	}
+				if !ok || !v1.Theme.Equal(v2) {
+					eq = false
+					break
+				}
+			}
 
-	tflog.Trace(ctx, "updated the account's signing theme resource")
+			if !eq {
+				return t, "waiting", nil
+			}
 
-	return resourceAccountSigningThemesRead(ctx, d, meta)
+			return t, "complete", nil
+		},
+	}
 }
 
-func resourceAccountSigningThemesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
-
-	var diags diag.Diagnostics
+// setResourceData sets all the themes of the account, the theme of the prior state first. The other themes show
+// as removed in the plan, see validateAccountSigningThemesDiff.
+func setResourceData(d *schema.ResourceData, v interface{}, meta interface{}) error {
+	ts := v.(ossign.SigningThemes)
 
-	if err := c.DeleteAccountSigningThemes(); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
+	var n string
+	if p, ok := d.Get("theme").([]interface{}); ok && len(p) > 0 && p[0] != nil {
+		n = p[0].(map[string]interface{})["name"].(string)
 	}
 
-	tflog.Trace(ctx, "waiting for the signing theme resource to be deleted...")
-
-	scc := getSigningThemeStateChangeConf(c, map[string]ossign.SigningTheme{})
-	_, err := scc.WaitForStateContext(ctx)
+	r := make([]interface{}, 0, len(ts))
 
-	if err != nil {
-		return diag.FromErr(err)
+	if t, ok := ts.Get(n); ok {
+		r = append(r, flattenAccountSigningTheme(n, t))
 	}
 
-	tflog.Trace(ctx, "deleted the account's signing theme resource")
-
-	d.SetId("")
+	for _, t := range ts {
+		if t.Name != n {
+			r = append(r, flattenAccountSigningTheme(t.Name, t.Theme))
+		}
+	}
 
-	return diags
+	return d.Set("theme", r)
 }
@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccResourceSigningThemes(t *testing.T) {
@@ -19,9 +23,9 @@ func TestAccResourceSigningThemes(t *testing.T) {
 	th2 := generateSigningTheme()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSigningThemesResourceDestroyed,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSigningThemesResourceDestroyed,
 		Steps: []resource.TestStep{
 			{
 				PreConfig: testAccSigningThemesPreTestCleanup,
@@ -52,8 +56,8 @@ func TestAccResourceSigningThemes(t *testing.T) {
 							"signature_button":          th.SignatureButton,
 							"optional_signature_button": th.OptionalSignatureButton,
 						}),
-					testAccCheckSigningThemesResourceMatches(map[string]ossign.SigningTheme{
-						"default": th,
+					testAccCheckSigningThemesResourceMatches(ossign.SigningThemes{
+						{Name: "default", Theme: th},
 					}),
 				),
 			},
@@ -85,8 +89,8 @@ func TestAccResourceSigningThemes(t *testing.T) {
 							"signature_button":          th2.SignatureButton,
 							"optional_signature_button": th2.OptionalSignatureButton,
 						}),
-					testAccCheckSigningThemesResourceMatches(map[string]ossign.SigningTheme{
-						"default": th2,
+					testAccCheckSigningThemesResourceMatches(ossign.SigningThemes{
+						{Name: "default", Theme: th2},
 					}),
 				),
 			},
@@ -119,7 +123,36 @@ func TestAccResourceSigningThemes(t *testing.T) {
 	})
 }
 
-func testAccCheckSigningThemesResourceMatches(m map[string]ossign.SigningTheme) resource.TestCheckFunc {
+func TestAccResourceSigningThemesAccessibilityLevel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`
+				resource "onespansign_account_signing_themes" "foo" {
+					accessibility_level = "AA"
+
+					theme {
+						name = "default"
+						primary = "#1A4F9C"
+						success = "#1E7A34"
+						warning = "#F2C200"
+						error = "#B3261E"
+						info = "#0B6BA8"
+						signature_button = "lightyellow"
+						optional_signature_button = "#595959"
+					}
+				}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`signature_button: the contrast ratio of lightyellow with the #FFFFFF text is 1.02:1`),
+			},
+		},
+	})
+}
+
+func testAccCheckSigningThemesResourceMatches(m ossign.SigningThemes) resource.TestCheckFunc {
 	return func(*terraform.State) error {
 		c := getTestApiClient()
 
@@ -128,11 +161,11 @@ func testAccCheckSigningThemesResourceMatches(m map[string]ossign.SigningTheme)
 			panic(err.GetError())
 		}
 
-		for k1, v1 := range l {
-			v2 := m[k1]
+		for _, v1 := range l {
+			v2, _ := m.Get(v1.Name)
 
-			if !cmp.Equal(v1, v2) {
-				fmt.Printf("Obtained remote value: %v\n", v1)
+			if !cmp.Equal(v1.Theme, v2) {
+				fmt.Printf("Obtained remote value: %v\n", v1.Theme)
 				fmt.Printf("Obtained local value: %v\n", v2)
 				return errors.New("Signing themes resource does not match expectation")
 			}
@@ -227,3 +260,169 @@ func generateSigningTheme() ossign.SigningTheme {
 func randHex() string {
 	return fmt.Sprintf("#%s", acctest.RandStringFromCharSet(6, "0123456789ABCDEF"))
 }
+
+func TestFlattenSigningThemeSections(t *testing.T) {
+	th := ossign.SigningTheme{
+		Primary: "#abcdef",
+		Sections: map[string]json.RawMessage{
+			"font": json.RawMessage(`{ "size": 14, "family": "Roboto" }`),
+		},
+	}
+
+	f := flattenAccountSigningTheme("default", th).(map[string]interface{})
+
+	assert.Equal(t, "#ABCDEF", f["primary"])
+	assert.Equal(t, map[string]interface{}{"font": `{"family":"Roboto","size":14}`}, f["sections"])
+	assert.True(t, th.Equal(expandSigningTheme(f)))
+
+	f["sections"] = map[string]interface{}{}
+	assert.Nil(t, expandSigningTheme(f).Sections)
+}
+
+func TestValidateSigningThemeSections(t *testing.T) {
+	assert.False(t, validateSigningThemeSections(map[string]interface{}{
+		"font":       `{"family": "Roboto"}`,
+		"background": `{}`,
+	}, cty.GetAttrPath("sections")).HasError())
+
+	for _, s := range []map[string]interface{}{
+		{"color": `{"primary": "#FFFFFF"}`},
+		{"font": `Roboto`},
+		{"font": `["Roboto"]`},
+		{"font": `null`},
+	} {
+		assert.True(t, validateSigningThemeSections(s, cty.GetAttrPath("sections")).HasError(), s)
+	}
+}
+
+func TestResourceAccountSigningThemesStateUpgradeV0(t *testing.T) {
+	// State stored by the version 0 of the resource, where `theme` was a set
+	raw := `{
+		"id": "account-id",
+		"theme": [
+			{
+				"name": "default",
+				"primary": "#1A4F9C",
+				"success": "#2E7D32",
+				"warning": "#F2C200",
+				"error": "#C62828",
+				"info": "#1565C0",
+				"signature_button": "#1A4F9C",
+				"optional_signature_button": "#5C6BC0"
+			}
+		]
+	}`
+
+	s := testUpgradeResourceState(t, "onespansign_account_signing_themes", 0, raw)
+
+	assert.Equal(t, "account-id", s.GetAttr("id").AsString())
+
+	ths := s.GetAttr("theme")
+	if assert.True(t, ths.Type().IsListType()) && assert.Equal(t, 1, ths.LengthInt()) {
+		th := ths.Index(cty.NumberIntVal(0))
+
+		assert.Equal(t, "default", th.GetAttr("name").AsString())
+		assert.Equal(t, "#1A4F9C", th.GetAttr("primary").AsString())
+		assert.Equal(t, "#5C6BC0", th.GetAttr("optional_signature_button").AsString())
+	}
+
+	if err := resourceAccountSigningThemesV0().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid version 0 schema: %s", err)
+	}
+}
+
+func TestSigningThemesAccessibilityDiff(t *testing.T) {
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+
+	config := map[string]interface{}{
+		"accessibility_level": accessibilityLevelAA,
+		"check_color_vision":  true,
+		"theme": []interface{}{
+			map[string]interface{}{
+				"name":                      "default",
+				"primary":                   "#1A4F9C",
+				"success":                   "#00A000",
+				"warning":                   "#F2C200",
+				"error":                     "#D04000",
+				"info":                      "#0B6BA8",
+				"signature_button":          "lightyellow",
+				"optional_signature_button": "#767676",
+			},
+		},
+	}
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_signing_themes", nil, config)
+	if assert.NotEmpty(t, diags) {
+		assert.Equal(t, tfprotov5.DiagnosticSeverityError, diags[0].Severity)
+		assert.Contains(t, diags[0].Summary, "signature_button: the contrast ratio of lightyellow")
+	}
+
+	// The color vision deficiencies and the contrast are reported as warnings during the plan
+	config["accessibility_severity"] = accessibilitySeverityWarning
+
+	m = newProviderMeta(nil)
+	m.accountId = "account1"
+
+	_, diags = testPlanResourceChange(t, m, "onespansign_account_signing_themes", nil, config)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+		assert.Contains(t, diags[0].Summary, "don't meet the WCAG AA accessibility level")
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[1].Severity)
+		assert.Contains(t, diags[1].Summary, "hard to tell apart")
+	}
+}
+
+func TestSigningThemesSeveralThemes(t *testing.T) {
+	theme := func(n string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                      n,
+			"primary":                   "#1A4F9C",
+			"success":                   "#2E7D32",
+			"warning":                   "#F2C200",
+			"error":                     "#C62828",
+			"info":                      "#1565C0",
+			"signature_button":          "#1A4F9C",
+			"optional_signature_button": "#5C6BC0",
+			"sections":                  map[string]interface{}{},
+		}
+	}
+
+	// The theme of the state comes first, followed by the themes added outside of Terraform
+	d := resourceAccountSigningThemes().Data(&terraform.InstanceState{
+		ID: "account1",
+		Attributes: map[string]string{
+			"id":           "account1",
+			"theme.#":      "1",
+			"theme.0.name": "managed",
+		},
+	})
+
+	assert.NoError(t, setResourceData(d, ossign.SigningThemes{
+		{Name: "other", Theme: ossign.SigningTheme{Primary: "#000000"}},
+		{Name: "managed", Theme: ossign.SigningTheme{Primary: "#1A4F9C"}},
+	}, nil))
+
+	assert.Equal(t, "managed", d.Get("theme.0.name"))
+	assert.Equal(t, "other", d.Get("theme.1.name"))
+
+	// The plan warns about the themes removed by the apply
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+
+	prior := map[string]interface{}{
+		"id":         "account1",
+		"on_destroy": "reset_to_default",
+		"theme":      []interface{}{theme("managed"), theme("other")},
+	}
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_signing_themes", prior, map[string]interface{}{
+		"theme": []interface{}{theme("managed")},
+	})
+
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, diags[0].Severity)
+		assert.Equal(t, "Signing themes removed from the account", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, `the following themes will be removed: "other"`)
+	}
+}
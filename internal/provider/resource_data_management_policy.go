@@ -2,107 +2,162 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/internal/helpers"
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDataManagementPolicy() *schema.Resource {
-	return &schema.Resource{
-		Description: `OneSpan Sign account's data management policy.
+	r := dataManagementPolicySingleton()
+
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceDataManagementPolicyV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeSetBlockToList,
+		},
+	}
 
-Please note that this resource is a singleton, which means that only one instance of this resource should
-exist. Having multiple instances may produce unexpected result.`,
+	return r.Resource()
+}
 
-		CreateContext: resourceDataManagementPolicyCreate,
-		ReadContext:   resourceDataManagementPolicyRead,
-		UpdateContext: resourceDataManagementPolicyUpdate,
-		DeleteContext: resourceDataManagementPolicyDelete,
+// resourceDataManagementPolicyV0 is the version 0 of the resource, where the `transaction_retention` block was a set.
+func resourceDataManagementPolicyV0() *schema.Resource {
+	r := dataManagementPolicySingleton().Resource()
+	r.Schema["transaction_retention"].Type = schema.TypeSet
+
+	return r
+}
+
+func dataManagementPolicySingleton() *singletonResource {
+	return &singletonResource{
+		TypeName:    "onespansign_data_management_policy",
+		Name:        "data management policy",
+		Description: "OneSpan Sign account's data management policy.",
+
+		Get: func(c *ossign.ApiClient) (interface{}, *ossign.ApiError) {
+			dmp, apiErr := c.GetDataManagementPolicy()
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			return *dmp, nil
+		},
+		Set: func(c *ossign.ApiClient, v interface{}) *ossign.ApiError {
+			return updateDataManagementPolicy(c, v.(ossign.DataManagementPolicy))
+		},
+		Flatten: setDataManagementPolicyResourceData,
+		Default: ossign.DataManagementPolicy{
+			TransactionRetention: ossign.TransactionRetention{
+				Draft:                   json.Number("0"),
+				Sent:                    json.Number("0"),
+				Completed:               json.Number("0"),
+				Archived:                json.Number("0"),
+				Declined:                json.Number("0"),
+				OptedOut:                json.Number("0"),
+				Expired:                 json.Number("0"),
+				LifetimeTotal:           json.Number("120"),
+				LifetimeUntilCompletion: json.Number("120"),
+				IncludeSent:             false,
+			},
+		},
+		Expand:        buildDataManagementPolicy,
+		CustomizeDiff: validateDataManagementPolicyDiff,
+
+		// The settings that are not configured are owned by others, their changes are not drifts
+		ConfiguredDrift: true,
+		MergeConfigured: mergeConfiguredDataManagementPolicy,
+		OnDestroyNote:   " Only the configured transaction retention settings are written back, the others keep their remote value.",
 
 		Schema: map[string]*schema.Schema{
 			"transaction_retention": {
-				Description: "Transaction retention settings.",
-				Type:        schema.TypeSet,
-				Required:    true,
+				Description: "Transaction retention settings. Only the configured settings are managed, the others keep their remote value.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
 				MaxItems:    1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"draft": {
 							Description:      "Number of days to keep drafts for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"sent": {
 							Description: `Number of days to keep sent transactions for.
 							Note that it's not possible to specify an expiry value greater than the retention policy for Sent transactions.`,
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"completed": {
 							Description:      "Number of days to keep completed transactions for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"archived": {
 							Description:      "Number of days to keep archived transactions for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"declined": {
 							Description:      "Number of days to keep declined transactions for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"opted_out": {
 							Description:      "Number of days to keep opted-out transactions for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"expired": {
 							Description:      "Number of days to keep expired transactions for.",
 							Type:             schema.TypeInt,
-							Required:         true,
+							Optional:         true,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"lifetime_total": {
 							Description:      "Number of days to keep the transactions, calculated from the day that the transaction is created.",
 							Type:             schema.TypeInt,
 							Optional:         true,
-							Default:          120,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"lifetime_until_completion": {
 							Description:      "Number of days that incomplete transactions will be stored, calculated from the day that the transaction is created.",
 							Type:             schema.TypeInt,
 							Optional:         true,
-							Default:          120,
+							Computed:         true,
 							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 						},
 						"include_sent": {
 							Description: "Include sent transactions as part of the \"incomplete transactions\".",
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Default:     false,
+							Computed:    true,
 						},
 					},
 				},
 			},
 		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
 	}
 }
 
@@ -161,116 +216,168 @@ func flattenTransactionRetention(tr ossign.TransactionRetention) (interface{}, e
 	return trm, nil
 }
 
-func resourceDataManagementPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
+func setDataManagementPolicyResourceData(d *schema.ResourceData, v interface{}, meta interface{}) error {
+	dmp := v.(ossign.DataManagementPolicy)
 
-	var diags diag.Diagnostics
+	tr, err := flattenTransactionRetention(dmp.TransactionRetention)
 
-	diags = append(diags, diag.Diagnostic{
-		Severity: diag.Warning,
-		Summary:  "updating (replacing) resource instead of creating",
-		Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
-	})
+	if err != nil {
+		return err
+	}
 
-	diags = append(diags, resourceDataManagementPolicyUpdate(ctx, d, meta)...)
+	return d.Set("transaction_retention", []interface{}{tr})
+}
 
-	d.SetId(c.ClientId)
+// buildDataManagementPolicy builds the data management policy to write by merging the configured transaction
+// retention settings into the remote policy, so that the settings owned by others are left untouched.
+func buildDataManagementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, diag.Diagnostics) {
+	dmp, apiErr := meta.(*providerMeta).client.GetDataManagementPolicy()
+	if apiErr != nil {
+		return nil, apiErrorDiags(apiErr)
+	}
 
-	return diags
-}
+	trs := d.Get("transaction_retention").([]interface{})
+	cfg := d.GetRawConfig().GetAttr("transaction_retention")
 
-func resourceDataManagementPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
+	if len(trs) < 1 || trs[0] == nil || cfg.IsNull() || !cfg.IsKnown() || cfg.LengthInt() < 1 {
+		return *dmp, nil
+	}
 
-	var diags diag.Diagnostics
+	dmp.TransactionRetention = mergeTransactionRetention(dmp.TransactionRetention, cfg.Index(cty.NumberIntVal(0)), trs[0].(map[string]interface{}))
 
-	dmp, apiErr := c.GetDataManagementPolicy()
+	return *dmp, nil
+}
 
-	if apiErr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  apiErr.Summary,
-			Detail:   apiErr.Detail,
-		})
-		return diags
+// mergeTransactionRetention returns `tr` with the settings that are set in the configuration `c` replaced by
+// their value in `i`.
+func mergeTransactionRetention(tr ossign.TransactionRetention, c cty.Value, i map[string]interface{}) ossign.TransactionRetention {
+	configured := func(k string) bool {
+		return !c.IsNull() && c.Type().HasAttribute(k) && !c.GetAttr(k).IsNull()
 	}
 
-	tr, err := flattenTransactionRetention(dmp.TransactionRetention)
-
-	if err != nil {
-		return diag.FromErr(err)
+	for k, n := range map[string]*json.Number{
+		"draft":                     &tr.Draft,
+		"sent":                      &tr.Sent,
+		"completed":                 &tr.Completed,
+		"archived":                  &tr.Archived,
+		"declined":                  &tr.Declined,
+		"opted_out":                 &tr.OptedOut,
+		"expired":                   &tr.Expired,
+		"lifetime_total":            &tr.LifetimeTotal,
+		"lifetime_until_completion": &tr.LifetimeUntilCompletion,
+	} {
+		if configured(k) {
+			*n = helpers.GetJsonNumber(int64(i[k].(int)))
+		}
 	}
 
-	if err := d.Set("transaction_retention", []interface{}{tr}); err != nil {
-		return diag.FromErr(err)
+	if configured("include_sent") {
+		tr.IncludeSent = i["include_sent"].(bool)
 	}
 
-	return diags
+	return tr
 }
 
-func resourceDataManagementPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
-
-	var diags diag.Diagnostics
-	var tr *ossign.TransactionRetention
-
-	trs := d.Get("transaction_retention").(*schema.Set).List()
-
-	if len(trs) > 0 {
-		i := trs[0].(map[string]interface{})
-
-		tr = &ossign.TransactionRetention{
-			Draft:                   helpers.GetJsonNumber(int64(i["draft"].(int))),
-			Sent:                    helpers.GetJsonNumber(int64(i["sent"].(int))),
-			Completed:               helpers.GetJsonNumber(int64(i["completed"].(int))),
-			Archived:                helpers.GetJsonNumber(int64(i["archived"].(int))),
-			Declined:                helpers.GetJsonNumber(int64(i["declined"].(int))),
-			OptedOut:                helpers.GetJsonNumber(int64(i["opted_out"].(int))),
-			Expired:                 helpers.GetJsonNumber(int64(i["expired"].(int))),
-			LifetimeTotal:           helpers.GetJsonNumber(int64(i["lifetime_total"].(int))),
-			LifetimeUntilCompletion: helpers.GetJsonNumber(int64(i["lifetime_until_completion"].(int))),
-			IncludeSent:             i["include_sent"].(bool),
-		}
+// mergeConfiguredDataManagementPolicy returns the remote policy o with the transaction retention settings covered
+// by the configured paths ps replaced by their value in the policy v.
+func mergeConfiguredDataManagementPolicy(o interface{}, v interface{}, ps []interface{}) interface{} {
+	dmp := o.(ossign.DataManagementPolicy)
+	tr := &dmp.TransactionRetention
+	vtr := v.(ossign.DataManagementPolicy).TransactionRetention
+
+	configured := func(k string) bool {
+		return isConfiguredPath("transaction_retention[0]."+k, ps)
 	}
 
-	if tr != nil {
-		b := ossign.DataManagementPolicy{
-			TransactionRetention: *tr,
+	for k, n := range map[string][2]*json.Number{
+		"draft":                     {&tr.Draft, &vtr.Draft},
+		"sent":                      {&tr.Sent, &vtr.Sent},
+		"completed":                 {&tr.Completed, &vtr.Completed},
+		"archived":                  {&tr.Archived, &vtr.Archived},
+		"declined":                  {&tr.Declined, &vtr.Declined},
+		"opted_out":                 {&tr.OptedOut, &vtr.OptedOut},
+		"expired":                   {&tr.Expired, &vtr.Expired},
+		"lifetime_total":            {&tr.LifetimeTotal, &vtr.LifetimeTotal},
+		"lifetime_until_completion": {&tr.LifetimeUntilCompletion, &vtr.LifetimeUntilCompletion},
+	} {
+		if configured(k) {
+			*n[0] = *n[1]
 		}
+	}
 
-		if apiErr := c.UpdateDataManagementPolicy(b); apiErr != nil {
-			// There are undocumented validation errors that occur sometimes on a seemingly valid payload.
-			// This special handling is added to easily debug the issue.
-			if apiErr.HttpResponse != nil && apiErr.HttpResponse.StatusCode%400 < 100 {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  apiErr.Summary,
-					Detail:   fmt.Sprintf("4xx error occurred while updating the data management policy: %v", b),
-				})
-			}
+	if configured("include_sent") {
+		tr.IncludeSent = vtr.IncludeSent
+	}
 
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  apiErr.Summary,
-				Detail:   apiErr.Detail,
-			})
-			return diags
-		}
+	return dmp
+}
+
+// plannedSentRetentionKey is the key of the retention of sent transactions recorded in the providerMeta.
+const plannedSentRetentionKey = "data_management_policy/sent"
+
+// validateDataManagementPolicyDiff validates the planned data management policy. The retention of sent
+// transactions cannot be lower than the expiry times of the expiry time configuration. When the expiry time
+// configuration was planned earlier in the run, the planned expiry times are used. Otherwise, the expiry times of
+// the account are read and a conflict is only reported as a warning, since they may still be changed in the same run.
+func validateDataManagementPolicyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The retention is unknown when it isn't configured for a new resource
+	if !d.NewValueKnown("transaction_retention") || !d.NewValueKnown("transaction_retention.0.sent") {
+		return nil
+	}
+
+	trs := d.Get("transaction_retention").([]interface{})
 
-		tflog.Trace(ctx, "updated the account's data management policy resource")
+	if len(trs) < 1 || trs[0] == nil {
+		return nil
 	}
 
-	return resourceDataManagementPolicyRead(ctx, d, meta)
+	sent := trs[0].(map[string]interface{})["sent"].(int)
+
+	// The provider is not configured yet, e.g. its configuration depends on other resources
+	m, ok := meta.(*providerMeta)
+	if !ok || m == nil {
+		return nil
+	}
+
+	m.setPlanned(plannedSentRetentionKey, sent)
+
+	if v, ok := m.getPlanned(plannedExpiryTimesKey); ok {
+		e := v.(plannedExpiryTimes)
+		return checkExpiryTimesRetention(e.Default, e.Maximum, sent)
+	}
+
+	etc, apiErr := m.client.GetExpiryTimeConfiguration()
+	if apiErr != nil {
+		return apiErr.GetError()
+	}
+
+	dft, err := helpers.GetInt(etc.Default)
+	if err != nil {
+		return err
+	}
+
+	mxm, err := helpers.GetInt(etc.Maximum)
+	if err != nil {
+		return err
+	}
+
+	if err := checkExpiryTimesRetention(dft, mxm, sent); err != nil {
+		addPlanWarning(ctx, "Retention of sent transactions conflicting with the expiry time configuration",
+			err.Error()+". Unless the expiry times are lowered by `onespansign_expiry_time_config` before this "+
+				"resource is applied, e.g. with `depends_on`, the apply will fail.")
+	}
+
+	return nil
 }
 
-func resourceDataManagementPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "no deletion will take place",
-			Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
-		},
+func updateDataManagementPolicy(c *ossign.ApiClient, b ossign.DataManagementPolicy) *ossign.ApiError {
+	apiErr := c.UpdateDataManagementPolicy(b)
+
+	// There are undocumented validation errors that occur sometimes on a seemingly valid payload.
+	// This special handling is added to easily debug the issue.
+	if apiErr != nil && apiErr.HttpResponse != nil && apiErr.HttpResponse.StatusCode%400 < 100 {
+		apiErr.Detail = fmt.Sprintf("4xx error occurred while updating the data management policy: %v\n%s", b, apiErr.Detail)
 	}
+
+	return apiErr
 }
@@ -1,25 +1,29 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/internal/helpers"
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccResourceDataManagementPolicy(t *testing.T) {
 	tr := generateTransactionRetention()
-	tr.LifetimeTotal = json.Number("120")           // default
-	tr.LifetimeUntilCompletion = json.Number("120") // default
-	tr.IncludeSent = false                          // default
 
-	tr2 := generateTransactionRetention()
+	// Partial config, only the `completed` retention is managed
+	tr2 := tr
+	tr2.Completed = generateTransactionRetention().Completed
 
 	// Default config
 	tr3 := ossign.TransactionRetention{
@@ -36,8 +40,8 @@ func TestAccResourceDataManagementPolicy(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: getTestConfig(fmt.Sprintf(`
@@ -50,23 +54,30 @@ func TestAccResourceDataManagementPolicy(t *testing.T) {
 						declined = %s
 						opted_out = %s
 						expired = %s
+						lifetime_total = %s
+						lifetime_until_completion = %s
+						include_sent = %s
 					}
 				}
 				`, tr.Draft.String(), tr.Sent.String(), tr.Completed.String(),
 					tr.Archived.String(), tr.Declined.String(), tr.OptedOut.String(),
-					tr.Expired.String(),
+					tr.Expired.String(), tr.LifetimeTotal.String(), tr.LifetimeUntilCompletion.String(),
+					strconv.FormatBool(tr.IncludeSent),
 				)),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("onespansign_data_management_policy.foo", "transaction_retention.#", "1"),
 					resource.TestCheckTypeSetElemNestedAttrs(
 						"onespansign_data_management_policy.foo", "transaction_retention.*", map[string]string{
-							"draft":     tr.Draft.String(),
-							"sent":      tr.Sent.String(),
-							"completed": tr.Completed.String(),
-							"archived":  tr.Archived.String(),
-							"declined":  tr.Declined.String(),
-							"opted_out": tr.OptedOut.String(),
-							"expired":   tr.Expired.String(),
+							"draft":                     tr.Draft.String(),
+							"sent":                      tr.Sent.String(),
+							"completed":                 tr.Completed.String(),
+							"archived":                  tr.Archived.String(),
+							"declined":                  tr.Declined.String(),
+							"opted_out":                 tr.OptedOut.String(),
+							"expired":                   tr.Expired.String(),
+							"lifetime_total":            tr.LifetimeTotal.String(),
+							"lifetime_until_completion": tr.LifetimeUntilCompletion.String(),
+							"include_sent":              strconv.FormatBool(tr.IncludeSent),
 						}),
 					testAccCheckDataManagementPolicyResourceMatches(ossign.DataManagementPolicy{
 						TransactionRetention: tr,
@@ -78,26 +89,26 @@ func TestAccResourceDataManagementPolicy(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "onespansign_data_management_policy.foo",
+				ImportState:       true,
+				ImportStateId:     "account",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "onespansign_data_management_policy.foo",
+				ImportState:   true,
+				ImportStateId: "not-the-account-id",
+				ExpectError:   regexp.MustCompile("does not match the configured OneSpan Sign account"),
+			},
 			{
 				Config: getTestConfig(fmt.Sprintf(`
 				resource "onespansign_data_management_policy" "foo" {
 					transaction_retention {
-						draft = %s
-						sent = %s
 						completed = %s
-						archived = %s
-						declined = %s
-						opted_out = %s
-						expired = %s
-						lifetime_total = %s
-						lifetime_until_completion = %s
-						include_sent = %s
 					}
 				}
-				`, tr2.Draft.String(), tr2.Sent.String(), tr2.Completed.String(),
-					tr2.Archived.String(), tr2.Declined.String(), tr2.OptedOut.String(),
-					tr2.Expired.String(), tr2.LifetimeTotal.String(), tr2.LifetimeUntilCompletion.String(),
-					strconv.FormatBool(tr2.IncludeSent),
+				`, tr2.Completed.String(),
 				)),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("onespansign_data_management_policy.foo", "transaction_retention.#", "1"),
@@ -211,3 +222,248 @@ func generateTransactionRetention() ossign.TransactionRetention {
 		IncludeSent:             helpers.RandBool(),
 	}
 }
+
+func TestResourceDataManagementPolicyStateUpgradeV0(t *testing.T) {
+	// State stored by the version 0 of the resource, where `transaction_retention` was a set
+	raw := `{
+		"id": "account-id",
+		"transaction_retention": [
+			{
+				"draft": 30,
+				"sent": 60,
+				"completed": 90,
+				"archived": 120,
+				"declined": 0,
+				"opted_out": 0,
+				"expired": 0,
+				"lifetime_total": 120,
+				"lifetime_until_completion": 120,
+				"include_sent": true
+			}
+		]
+	}`
+
+	s := testUpgradeResourceState(t, "onespansign_data_management_policy", 0, raw)
+
+	assert.Equal(t, "account-id", s.GetAttr("id").AsString())
+
+	trs := s.GetAttr("transaction_retention")
+	if assert.True(t, trs.Type().IsListType()) && assert.Equal(t, 1, trs.LengthInt()) {
+		tr := trs.Index(cty.NumberIntVal(0))
+
+		assert.True(t, tr.GetAttr("draft").RawEquals(cty.NumberIntVal(30)))
+		assert.True(t, tr.GetAttr("sent").RawEquals(cty.NumberIntVal(60)))
+		assert.True(t, tr.GetAttr("lifetime_until_completion").RawEquals(cty.NumberIntVal(120)))
+		assert.True(t, tr.GetAttr("include_sent").True())
+	}
+
+	if err := resourceDataManagementPolicyV0().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid version 0 schema: %s", err)
+	}
+}
+
+func TestMergeTransactionRetention(t *testing.T) {
+	tr := generateTransactionRetention()
+
+	c := cty.ObjectVal(map[string]cty.Value{
+		"draft":                     cty.NullVal(cty.Number),
+		"sent":                      cty.NullVal(cty.Number),
+		"completed":                 cty.NumberIntVal(15),
+		"archived":                  cty.NullVal(cty.Number),
+		"declined":                  cty.NullVal(cty.Number),
+		"opted_out":                 cty.NullVal(cty.Number),
+		"expired":                   cty.NullVal(cty.Number),
+		"lifetime_total":            cty.NullVal(cty.Number),
+		"lifetime_until_completion": cty.NullVal(cty.Number),
+		"include_sent":              cty.BoolVal(!tr.IncludeSent),
+	})
+
+	i := map[string]interface{}{
+		"draft":                     0,
+		"sent":                      0,
+		"completed":                 15,
+		"archived":                  0,
+		"declined":                  0,
+		"opted_out":                 0,
+		"expired":                   0,
+		"lifetime_total":            0,
+		"lifetime_until_completion": 0,
+		"include_sent":              !tr.IncludeSent,
+	}
+
+	expected := tr
+	expected.Completed = json.Number("15")
+	expected.IncludeSent = !tr.IncludeSent
+
+	assert.Equal(t, expected, mergeTransactionRetention(tr, c, i))
+	assert.Equal(t, tr, mergeTransactionRetention(tr, cty.NullVal(c.Type()), i))
+}
+
+func TestDataManagementPolicyConfiguredDrift(t *testing.T) {
+	tr := map[string]interface{}{
+		"draft":                   10,
+		"sent":                    60,
+		"completed":               90,
+		"archived":                0,
+		"declined":                0,
+		"optedOut":                0,
+		"expired":                 0,
+		"lifetimeTotal":           120,
+		"lifetimeUntilCompletion": 120,
+		"includeSent":             false,
+	}
+
+	m := newProviderMeta(testApiClient(t, map[string]interface{}{
+		"/api/dataRetentionSettings/dataManagementPolicy": map[string]interface{}{"transactionRetention": tr},
+		"/api/dataRetentionSettings/expiryTimeConfiguration": map[string]interface{}{
+			"remainingDays":        0,
+			"maximumRemainingDays": 0,
+		},
+	}))
+	m.accountId = "account1"
+	m.failOnDrift = true
+
+	s, diags := testPlanResourceChange(t, m, "onespansign_data_management_policy", nil, map[string]interface{}{
+		"transaction_retention": []interface{}{
+			map[string]interface{}{"sent": 60},
+		},
+	})
+	assert.Empty(t, diags)
+	assert.Equal(t, cty.ListVal([]cty.Value{cty.StringVal("transaction_retention[0].sent")}), s.GetAttr("configured_attributes"))
+
+	r := resourceDataManagementPolicy()
+
+	refresh := func(sent string) diag.Diagnostics {
+		d := r.Data(&terraform.InstanceState{
+			ID: "account1",
+			Attributes: map[string]string{
+				"id":                                                "account1",
+				"on_destroy":                                        "keep",
+				"configured_attributes.#":                           "1",
+				"configured_attributes.0":                           "transaction_retention[0].sent",
+				"transaction_retention.#":                           "1",
+				"transaction_retention.0.draft":                     "30",
+				"transaction_retention.0.sent":                      sent,
+				"transaction_retention.0.completed":                 "90",
+				"transaction_retention.0.archived":                  "0",
+				"transaction_retention.0.declined":                  "0",
+				"transaction_retention.0.opted_out":                 "0",
+				"transaction_retention.0.expired":                   "0",
+				"transaction_retention.0.lifetime_total":            "120",
+				"transaction_retention.0.lifetime_until_completion": "120",
+				"transaction_retention.0.include_sent":              "false",
+			},
+		})
+
+		return r.ReadContext(context.Background(), d, m)
+	}
+
+	// The draft retention isn't configured, its change is not a drift
+	assert.Empty(t, refresh("60"))
+
+	diags2 := refresh("30")
+	if assert.Len(t, diags2, 1) {
+		assert.Equal(t, diag.Error, diags2[0].Severity)
+		assert.Contains(t, diags2[0].Detail, `transaction_retention[0].sent: "30" => "60"`)
+		assert.NotContains(t, diags2[0].Detail, "draft")
+	}
+}
+
+func TestDataManagementPolicyDestroy(t *testing.T) {
+	remote := func() map[string]interface{} {
+		return map[string]interface{}{
+			"/api/dataRetentionSettings/dataManagementPolicy": map[string]interface{}{
+				"transactionRetention": map[string]interface{}{
+					"draft":                   10,
+					"sent":                    60,
+					"completed":               90,
+					"archived":                0,
+					"declined":                0,
+					"optedOut":                0,
+					"expired":                 0,
+					"lifetimeTotal":           120,
+					"lifetimeUntilCompletion": 120,
+					"includeSent":             true,
+				},
+			},
+		}
+	}
+
+	snapshot, err := encodeSnapshot(ossign.DataManagementPolicy{
+		TransactionRetention: ossign.TransactionRetention{
+			Draft:                   json.Number("99"),
+			Sent:                    json.Number("30"),
+			Completed:               json.Number("99"),
+			Archived:                json.Number("99"),
+			Declined:                json.Number("99"),
+			OptedOut:                json.Number("99"),
+			Expired:                 json.Number("99"),
+			LifetimeTotal:           json.Number("99"),
+			LifetimeUntilCompletion: json.Number("99"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		attributes map[string]string
+		expected   map[string]interface{}
+	}{
+		"reset-configured": {
+			attributes: map[string]string{
+				"on_destroy":              onDestroyResetToDefault,
+				"configured_attributes.#": "1",
+				"configured_attributes.0": "transaction_retention[0].sent",
+			},
+			expected: map[string]interface{}{"draft": "10", "sent": "0", "completed": "90", "includeSent": true},
+		},
+		"restore-configured": {
+			attributes: map[string]string{
+				"on_destroy":              onDestroyRestoreOriginal,
+				"original_value":          snapshot,
+				"configured_attributes.#": "2",
+				"configured_attributes.0": "transaction_retention[0].include_sent",
+				"configured_attributes.1": "transaction_retention[0].sent",
+			},
+			expected: map[string]interface{}{"draft": "10", "sent": "30", "completed": "90", "includeSent": false},
+		},
+		"nothing-configured": {
+			attributes: map[string]string{
+				"on_destroy":              onDestroyResetToDefault,
+				"configured_attributes.#": "0",
+			},
+			expected: map[string]interface{}{"draft": "10", "sent": "60", "completed": "90", "includeSent": true},
+		},
+		// The configured attributes were not recorded yet, the whole value is written back
+		"legacy-state": {
+			attributes: map[string]string{
+				"on_destroy": onDestroyResetToDefault,
+			},
+			expected: map[string]interface{}{"draft": "0", "sent": "0", "completed": "0", "includeSent": false},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res := remote()
+			m := newProviderMeta(testApiClient(t, res))
+
+			c.attributes["id"] = "account1"
+
+			r := resourceDataManagementPolicy()
+			d := r.Data(&terraform.InstanceState{ID: "account1", Attributes: c.attributes})
+
+			assert.Empty(t, r.DeleteContext(context.Background(), d, m))
+
+			tr := res["/api/dataRetentionSettings/dataManagementPolicy"].(map[string]interface{})["transactionRetention"].(map[string]interface{})
+			for k, v := range c.expected {
+				if n, ok := tr[k].(json.Number); ok {
+					assert.Equal(t, v, n.String(), k)
+				} else {
+					assert.Equal(t, v, tr[k], k)
+				}
+			}
+		})
+	}
+}
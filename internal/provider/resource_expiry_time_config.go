@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/internal/helpers"
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,16 +16,35 @@ import (
 )
 
 func resourceExpiryTimeConfig() *schema.Resource {
-	return &schema.Resource{
-		Description: `OneSpan Sign account's expiry configurations.
-		
-Please note that this resource is a singleton, which means that only one instance of this resource should
-exist. Having multiple instances may produce unexpected result.`,
+	return expiryTimeConfigSingleton().Resource()
+}
 
-		CreateContext: resourceExpiryTimeConfigCreate,
-		ReadContext:   resourceExpiryTimeConfigRead,
-		UpdateContext: resourceExpiryTimeConfigUpdate,
-		DeleteContext: resourceExpiryTimeConfigDelete,
+func expiryTimeConfigSingleton() *singletonResource {
+	return &singletonResource{
+		TypeName:    "onespansign_expiry_time_config",
+		Name:        "expiry time configuration",
+		Description: "OneSpan Sign account's expiry configurations.",
+
+		Get: func(c *ossign.ApiClient) (interface{}, *ossign.ApiError) {
+			etc, apiErr := c.GetExpiryTimeConfiguration()
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			return *etc, nil
+		},
+		Set: func(c *ossign.ApiClient, v interface{}) *ossign.ApiError {
+			return c.UpdateExpiryTimeConfiguration(v.(ossign.ExpiryTimeConfiguration))
+		},
+		Flatten: setExpiryTimeConfigResourceData,
+		Default: ossign.ExpiryTimeConfiguration{
+			Default: json.Number("0"),
+			Maximum: json.Number("0"),
+		},
+		Expand:        buildExpiryTimeConfig,
+		CustomizeDiff: validateExpiryTimeConfigDiff,
+		Waiter: func(c *ossign.ApiClient, e interface{}) resource.StateChangeConf {
+			return getExpiryTimeConfigStateChangeConf(c, e.(ossign.ExpiryTimeConfiguration))
+		},
 
 		Schema: map[string]*schema.Schema{
 			"default": {
@@ -41,28 +62,81 @@ exist. Having multiple instances may produce unexpected result.`,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
 		},
+	}
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+// plannedExpiryTimesKey is the key of the plannedExpiryTimes recorded in the providerMeta.
+const plannedExpiryTimesKey = "expiry_time_config"
+
+// plannedExpiryTimes are the expiry times of the expiry time configuration planned during the run.
+type plannedExpiryTimes struct {
+	Default int
+	Maximum int
+}
+
+// checkExpiryTimesRetention checks the expiry times dft and mxm against the retention of sent transactions sent.
+func checkExpiryTimesRetention(dft int, mxm int, sent int) error {
+	// A retention of 0 keeps the transactions indefinitely
+	if sent == 0 {
+		return nil
+	}
+
+	if dft > sent {
+		return fmt.Errorf("the `default` expiry time (%d) cannot be larger than the retention of sent transactions of the account's data management policy (%d)", dft, sent)
 	}
+
+	if mxm > sent {
+		return fmt.Errorf("the `maximum` expiry time (%d) cannot be larger than the retention of sent transactions of the account's data management policy (%d)", mxm, sent)
+	}
+
+	return nil
 }
 
-func validateFields(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+// validateExpiryTimeConfigDiff validates the planned expiry time configuration. The expiry time cannot be
+// larger than the retention of sent transactions. When the data management policy was planned earlier in the
+// run, the planned retention is used. Otherwise, the retention of the account is read and a conflict is only
+// reported as a warning, since the policy may still be changed in the same run.
+func validateExpiryTimeConfigDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("default") || !d.NewValueKnown("maximum") {
+		return nil
+	}
 
 	dft := d.Get("default").(int)
 	mxm := d.Get("maximum").(int)
 
 	if mxm > 0 && dft > mxm {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "validation error",
-			Detail:   "the `default` value cannot be larger than the `maximum` value",
-		})
+		return errors.New("the `default` value cannot be larger than the `maximum` value")
+	}
+
+	// The provider is not configured yet, e.g. its configuration depends on other resources
+	m, ok := meta.(*providerMeta)
+	if !ok || m == nil {
+		return nil
+	}
+
+	m.setPlanned(plannedExpiryTimesKey, plannedExpiryTimes{Default: dft, Maximum: mxm})
+
+	if sent, ok := m.getPlanned(plannedSentRetentionKey); ok {
+		return checkExpiryTimesRetention(dft, mxm, sent.(int))
 	}
 
-	return diags
+	dmp, apiErr := m.client.GetDataManagementPolicy()
+	if apiErr != nil {
+		return apiErr.GetError()
+	}
+
+	sent, err := helpers.GetInt(dmp.TransactionRetention.Sent)
+	if err != nil {
+		return err
+	}
+
+	if err := checkExpiryTimesRetention(dft, mxm, sent); err != nil {
+		addPlanWarning(ctx, "Expiry time conflicting with the data management policy",
+			err.Error()+". Unless the retention is raised by `onespansign_data_management_policy` before this "+
+				"resource is applied, e.g. with `depends_on`, the apply will fail.")
+	}
+
+	return nil
 }
 
 // getExpiryTimeConfigStateChangeConf gets the configuration struct for the `WaitForState` functions.
@@ -91,100 +165,19 @@ func getExpiryTimeConfigStateChangeConf(c *ossign.ApiClient, e ossign.ExpiryTime
 	}
 }
 
-func resourceExpiryTimeConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*ossign.ApiClient)
-
-	diags := validateFields(ctx, d, meta)
-
-	if len(diags) > 0 {
-		return diags
-	}
-
-	diags = append(diags, diag.Diagnostic{
-		Severity: diag.Warning,
-		Summary:  "updating (replacing) resource instead of creating",
-		Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
-	})
-
-	diags = append(diags, resourceExpiryTimeConfigUpdate(ctx, d, meta)...)
-
-	d.SetId(c.ClientId)
-
-	return diags
-}
-
-func resourceExpiryTimeConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
-
-	var diags diag.Diagnostics
-
-	etc, err := c.GetExpiryTimeConfiguration()
-
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
-	}
+func setExpiryTimeConfigResourceData(d *schema.ResourceData, v interface{}, meta interface{}) error {
+	etc := v.(ossign.ExpiryTimeConfiguration)
 
 	if err := d.Set("default", etc.Default); err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
-	if err := d.Set("maximum", etc.Maximum); err != nil {
-		return diag.FromErr(err)
-	}
-
-	return diags
+	return d.Set("maximum", etc.Maximum)
 }
 
-func resourceExpiryTimeConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*ossign.ApiClient)
-
-	diags := validateFields(ctx, d, meta)
-
-	if len(diags) > 0 {
-		return diags
-	}
-
-	b := ossign.ExpiryTimeConfiguration{
+func buildExpiryTimeConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, diag.Diagnostics) {
+	return ossign.ExpiryTimeConfiguration{
 		Default: helpers.GetJsonNumber(int64(d.Get("default").(int))),
 		Maximum: helpers.GetJsonNumber(int64(d.Get("maximum").(int))),
-	}
-
-	if err := c.UpdateExpiryTimeConfiguration(b); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  err.Summary,
-			Detail:   err.Detail,
-		})
-		return diags
-	}
-
-	tflog.Trace(ctx, "waiting for the account's expiry time configuration resource to be updated...")
-
-	scc := getExpiryTimeConfigStateChangeConf(c, b)
-	_, err := scc.WaitForStateContext(ctx)
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	tflog.Trace(ctx, "updated the account's expiry time configuration resource")
-
-	return resourceExpiryTimeConfigRead(ctx, d, meta)
-}
-
-func resourceExpiryTimeConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "no deletion will take place",
-			Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
-		},
-	}
+	}, nil
 }
@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/internal/helpers"
 	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccResourceExpiryTimeConfig(t *testing.T) {
@@ -29,8 +32,8 @@ func TestAccResourceExpiryTimeConfig(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: getTestConfig(fmt.Sprintf(`
@@ -105,3 +108,157 @@ func generateExpiryTimeConfig() ossign.ExpiryTimeConfiguration {
 		Maximum: helpers.RandJsonNumber(30, 40),
 	}
 }
+
+func TestAccResourceExpiryTimeConfigRestoreOriginal(t *testing.T) {
+	var o ossign.ExpiryTimeConfiguration
+
+	etc := generateExpiryTimeConfig()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckExpiryTimeConfigResourceMatches(o)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					p, err := getTestApiClient().GetExpiryTimeConfiguration()
+					if err != nil {
+						panic(err.GetError())
+					}
+					o = *p
+				},
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_expiry_time_config" "foo" {
+					default = %s
+					maximum = %s
+					on_destroy = "restore_original"
+				}
+				`, etc.Default.String(), etc.Maximum.String())),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_expiry_time_config.foo", "on_destroy", "restore_original"),
+					resource.TestCheckResourceAttrSet("onespansign_expiry_time_config.foo", "original_value"),
+					testAccCheckExpiryTimeConfigResourceMatches(etc),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceExpiryTimeConfigDuplicate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`
+				resource "onespansign_expiry_time_config" "foo" {
+					default = 0
+					maximum = 0
+				}
+
+				resource "onespansign_expiry_time_config" "bar" {
+					default = 0
+					maximum = 0
+				}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("declared more than once"),
+			},
+		},
+	})
+}
+
+func TestAccResourceExpiryTimeConfigValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(`
+				resource "onespansign_expiry_time_config" "foo" {
+					default = 20
+					maximum = 10
+				}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot be larger than the `maximum` value"),
+			},
+		},
+	})
+}
+
+func TestExpiryTimeRetentionDiff(t *testing.T) {
+	newMeta := func(sent int, dft int, mxm int) *providerMeta {
+		m := newProviderMeta(testApiClient(t, map[string]interface{}{
+			"/api/dataRetentionSettings/dataManagementPolicy": map[string]interface{}{
+				"transactionRetention": map[string]interface{}{"sent": sent},
+			},
+			"/api/dataRetentionSettings/expiryTimeConfiguration": map[string]interface{}{
+				"remainingDays":        dft,
+				"maximumRemainingDays": mxm,
+			},
+		}))
+		m.accountId = "account1"
+
+		return m
+	}
+
+	dmp := func(sent int) map[string]interface{} {
+		return map[string]interface{}{
+			"transaction_retention": []interface{}{
+				map[string]interface{}{"sent": sent},
+			},
+		}
+	}
+
+	etc := func(dft int, mxm int) map[string]interface{} {
+		return map[string]interface{}{
+			"default": dft,
+			"maximum": mxm,
+		}
+	}
+
+	severities := func(diags []*tfprotov5.Diagnostic) []tfprotov5.DiagnosticSeverity {
+		var r []tfprotov5.DiagnosticSeverity
+		for _, d := range diags {
+			r = append(r, d.Severity)
+		}
+		return r
+	}
+
+	// Conflicting with the remote retention, which may still be raised in the same run
+	m := newMeta(30, 0, 0)
+	_, diags := testPlanResourceChange(t, m, "onespansign_expiry_time_config", nil, etc(60, 90))
+	assert.Equal(t, []tfprotov5.DiagnosticSeverity{tfprotov5.DiagnosticSeverityWarning}, severities(diags))
+
+	// Conflicting with the remote expiry times, which may still be lowered in the same run
+	m = newMeta(90, 60, 90)
+	_, diags = testPlanResourceChange(t, m, "onespansign_data_management_policy", nil, dmp(30))
+	assert.Equal(t, []tfprotov5.DiagnosticSeverity{tfprotov5.DiagnosticSeverityWarning}, severities(diags))
+
+	// Raising the retention and the expiry times together, in either order
+	m = newMeta(30, 30, 30)
+	_, diags = testPlanResourceChange(t, m, "onespansign_data_management_policy", nil, dmp(90))
+	assert.Empty(t, diags)
+	_, diags = testPlanResourceChange(t, m, "onespansign_expiry_time_config", nil, etc(60, 90))
+	assert.Empty(t, diags)
+
+	m = newMeta(30, 30, 30)
+	_, diags = testPlanResourceChange(t, m, "onespansign_expiry_time_config", nil, etc(60, 90))
+	assert.Equal(t, []tfprotov5.DiagnosticSeverity{tfprotov5.DiagnosticSeverityWarning}, severities(diags))
+	_, diags = testPlanResourceChange(t, m, "onespansign_data_management_policy", nil, dmp(90))
+	assert.Empty(t, diags)
+
+	// Conflicting with the retention planned in the same run
+	m = newMeta(90, 30, 30)
+	_, diags = testPlanResourceChange(t, m, "onespansign_data_management_policy", nil, dmp(30))
+	assert.Empty(t, diags)
+	_, diags = testPlanResourceChange(t, m, "onespansign_expiry_time_config", nil, etc(60, 90))
+	assert.Equal(t, []tfprotov5.DiagnosticSeverity{tfprotov5.DiagnosticSeverityError}, severities(diags))
+
+	// The provider is not configured yet
+	_, diags = testPlanResourceChange(t, nil, "onespansign_expiry_time_config", nil, etc(60, 90))
+	assert.Empty(t, diags)
+}
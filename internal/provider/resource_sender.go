@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	onDestroyDelete     = "delete"
+	onDestroyDeactivate = "deactivate"
+)
+
+// emailPattern loosely matches email addresses, the API checks them thoroughly.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+func resourceSender() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Sender of the OneSpan Sign account, i.e. a user who can create and send transactions.\n\n" +
+			"Senders are invited to the account by email, unless they are created with the `ACTIVE` status. " +
+			"Accepting the invitation activates the sender, which is not reported as a change.",
+
+		CreateContext: resourceSenderCreate,
+		ReadContext:   resourceSenderRead,
+		UpdateContext: resourceSenderUpdate,
+		DeleteContext: resourceSenderDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := d.Set("on_destroy", onDestroyDelete); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Description:      "Email address of the sender. Changing it replaces the sender.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(emailPattern, "must be an email address")),
+			},
+			"first_name": {
+				Description:      "First name of the sender.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"last_name": {
+				Description:      "Last name of the sender.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"company": {
+				Description: "Company of the sender.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"title": {
+				Description: "Title of the sender.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"phone": {
+				Description: "Phone number of the sender.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"language": {
+				Description:      "Language of the sender's emails and user interface. Defaults to the language of the account.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(languageCodePattern, "must be a language code")),
+			},
+			"type": {
+				Description: "Role of the sender in the account: `REGULAR` or `MANAGER`. Managers can manage the account's " +
+					"senders and settings. Defaults to `REGULAR`.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(ossign.SenderTypeRegular),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(ossign.SenderTypeRegular), string(ossign.SenderTypeManager),
+				}, false)),
+			},
+			"status": {
+				Description: "Status of the sender: `INVITED` sends an email inviting the sender to the account, `ACTIVE` " +
+					"creates the sender without an invitation and `LOCKED` deactivates the sender, who can no longer log in " +
+					"nor send transactions. Defaults to `INVITED`.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(ossign.SenderStatusInvited),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(ossign.SenderStatusInvited), string(ossign.SenderStatusActive), string(ossign.SenderStatusLocked),
+				}, false)),
+				// Imported senders who accepted their invitation are active, inviting them again is not a change
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return flattenSenderStatus(new, ossign.SenderStatus(old)) == new && d.Get("current_status").(string) == old
+				},
+			},
+			"current_status": {
+				Description: "Status of the sender in the account, `ACTIVE` once an invited sender accepted the invitation.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"on_destroy": {
+				Description: "What to do with the sender when the resource is destroyed: `delete` removes the sender from the account " +
+					"and `deactivate` locks the sender, e.g. to keep the transactions they own. Senders who still own transactions " +
+					"can't be deleted. Defaults to `delete`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          onDestroyDelete,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{onDestroyDelete, onDestroyDeactivate}, false)),
+			},
+		},
+	}
+}
+
+// expandSender builds the sender from the resource data. The status is only set when it is configured to change,
+// so that the senders who accepted their invitation are not invited again.
+func expandSender(d *schema.ResourceData) ossign.Sender {
+	s := ossign.Sender{
+		Email:     d.Get("email").(string),
+		FirstName: d.Get("first_name").(string),
+		LastName:  d.Get("last_name").(string),
+		Company:   d.Get("company").(string),
+		Title:     d.Get("title").(string),
+		Phone:     d.Get("phone").(string),
+		Language:  d.Get("language").(string),
+		Type:      ossign.SenderType(d.Get("type").(string)),
+	}
+
+	if d.IsNewResource() || d.HasChange("status") {
+		s.Status = ossign.SenderStatus(d.Get("status").(string))
+	}
+
+	return s
+}
+
+// flattenSenderStatus returns the status of the state from the configured status c and the remote status r. The
+// invited senders become active when they accept the invitation, which is not a change of the configuration.
+func flattenSenderStatus(c string, r ossign.SenderStatus) string {
+	if c == string(ossign.SenderStatusInvited) && r == ossign.SenderStatusActive {
+		return c
+	}
+
+	return string(r)
+}
+
+func resourceSenderCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s, apiErr := meta.(*providerMeta).client.CreateSender(expandSender(d))
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	d.SetId(s.Id)
+
+	return resourceSenderRead(ctx, d, meta)
+}
+
+func resourceSenderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s, apiErr := meta.(*providerMeta).client.GetSender(d.Id())
+	if apiErr != nil {
+		if apiErr.IsNotFound() {
+			tflog.Warn(ctx, "the sender was removed outside of Terraform, removing it from the state", map[string]interface{}{
+				"id": d.Id(),
+			})
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(apiErr)
+	}
+
+	v := map[string]interface{}{
+		"email":          s.Email,
+		"first_name":     s.FirstName,
+		"last_name":      s.LastName,
+		"company":        s.Company,
+		"title":          s.Title,
+		"phone":          s.Phone,
+		"language":       s.Language,
+		"type":           string(s.Type),
+		"status":         flattenSenderStatus(d.Get("status").(string), s.Status),
+		"current_status": string(s.Status),
+	}
+
+	for k, i := range v {
+		if err := d.Set(k, i); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSenderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if apiErr := meta.(*providerMeta).client.UpdateSender(d.Id(), expandSender(d)); apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	return resourceSenderRead(ctx, d, meta)
+}
+
+func resourceSenderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var apiErr *ossign.ApiError
+
+	if d.Get("on_destroy").(string) == onDestroyDeactivate {
+		s := expandSender(d)
+		s.Status = ossign.SenderStatusLocked
+
+		apiErr = c.UpdateSender(d.Id(), s)
+	} else {
+		apiErr = c.DeleteSender(d.Id())
+	}
+
+	// The sender is already gone
+	if apiErr != nil && !apiErr.IsNotFound() {
+		return apiErrorDiags(apiErr)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccResourceSender(t *testing.T) {
+	email := fmt.Sprintf("terraform-%s@example.com", uuid.NewString())
+	email2 := fmt.Sprintf("terraform-%s@example.com", uuid.NewString())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSenderDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_sender" "foo" {
+					email = "%s"
+					first_name = "Jane"
+					last_name = "Doe"
+					company = "Breathe Life"
+				}
+
+				resource "onespansign_sender" "bar" {
+					email = "%s"
+					first_name = "John"
+					last_name = "Doe"
+					type = "MANAGER"
+					status = "ACTIVE"
+					on_destroy = "deactivate"
+				}
+				`, email, email2)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "email", email),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "type", "REGULAR"),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "status", "INVITED"),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "current_status", "INVITED"),
+					resource.TestCheckResourceAttrSet("onespansign_sender.foo", "language"),
+					resource.TestCheckResourceAttr("onespansign_sender.bar", "type", "MANAGER"),
+					resource.TestCheckResourceAttr("onespansign_sender.bar", "current_status", "ACTIVE"),
+				),
+			},
+			{
+				ResourceName:      "onespansign_sender.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: getTestConfig(fmt.Sprintf(`
+				resource "onespansign_sender" "foo" {
+					email = "%s"
+					first_name = "Jane"
+					last_name = "Smith"
+					title = "Underwriter"
+					status = "LOCKED"
+				}
+
+				resource "onespansign_sender" "bar" {
+					email = "%s"
+					first_name = "John"
+					last_name = "Doe"
+					type = "MANAGER"
+					status = "ACTIVE"
+					on_destroy = "deactivate"
+				}
+				`, email, email2)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "last_name", "Smith"),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "company", ""),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "title", "Underwriter"),
+					resource.TestCheckResourceAttr("onespansign_sender.foo", "current_status", "LOCKED"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSenderDestroyed checks that the senders are deleted, or deactivated with `on_destroy = "deactivate"`.
+func testAccCheckSenderDestroyed(s *terraform.State) error {
+	c := getTestApiClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "onespansign_sender" {
+			continue
+		}
+
+		sender, apiErr := c.GetSender(rs.Primary.ID)
+		if apiErr != nil {
+			if apiErr.IsNotFound() {
+				continue
+			}
+
+			return apiErr.GetError()
+		}
+
+		if rs.Primary.Attributes["on_destroy"] != onDestroyDeactivate {
+			return fmt.Errorf("Sender %q still exists", rs.Primary.ID)
+		}
+
+		if sender.Status != ossign.SenderStatusLocked {
+			return fmt.Errorf("Sender %q is %s, expected %s", rs.Primary.ID, sender.Status, ossign.SenderStatusLocked)
+		}
+
+		// Deactivated senders are not cleaned up by the provider
+		if apiErr := c.DeleteSender(rs.Primary.ID); apiErr != nil && !apiErr.IsNotFound() {
+			return apiErr.GetError()
+		}
+	}
+
+	return nil
+}
+
+func TestFlattenSenderStatus(t *testing.T) {
+	// Accepting the invitation is not a change
+	assert.Equal(t, "INVITED", flattenSenderStatus("INVITED", ossign.SenderStatusInvited))
+	assert.Equal(t, "INVITED", flattenSenderStatus("INVITED", ossign.SenderStatusActive))
+
+	// Other transitions are reported
+	assert.Equal(t, "LOCKED", flattenSenderStatus("INVITED", ossign.SenderStatusLocked))
+	assert.Equal(t, "INVITED", flattenSenderStatus("ACTIVE", ossign.SenderStatusInvited))
+	assert.Equal(t, "LOCKED", flattenSenderStatus("ACTIVE", ossign.SenderStatusLocked))
+	assert.Equal(t, "ACTIVE", flattenSenderStatus("LOCKED", ossign.SenderStatusActive))
+
+	// Imported senders have no configured status
+	assert.Equal(t, "ACTIVE", flattenSenderStatus("", ossign.SenderStatusActive))
+}
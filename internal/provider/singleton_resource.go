@@ -0,0 +1,682 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const singletonDescriptionNote = `Please note that this resource is a singleton, which means that only one instance of this resource should
+exist for an account. Declaring multiple instances is reported as an error during the plan.`
+
+// singletonResource describes an account-level setting of OneSpan Sign. These settings always exist on the
+// account and can only be retrieved or replaced.
+//
+// A setting only declares its schema, how it is read from and written to the resource data, and how it is
+// read from and written to the API. The CRUD functions, ID and import handling are supplied by Resource,
+// so that all the account settings behave consistently.
+type singletonResource struct {
+	// TypeName is the name of the resource type in the provider (e.g. "onespansign_data_management_policy").
+	TypeName string
+
+	// Name of the setting, used in log messages (e.g. "data management policy").
+	Name string
+
+	// Description of the resource, used by the documentation generator and the language server.
+	// A note about the singleton behaviour is appended to it.
+	Description string
+
+	// Schema of the resource.
+	Schema map[string]*schema.Schema
+
+	// Get retrieves the remote value of the setting.
+	Get func(c *ossign.ApiClient) (interface{}, *ossign.ApiError)
+
+	// Set replaces the remote value of the setting with v.
+	Set func(c *ossign.ApiClient, v interface{}) *ossign.ApiError
+
+	// Flatten writes the remote value v to the resource data.
+	Flatten func(d *schema.ResourceData, v interface{}, meta interface{}) error
+
+	// Expand builds the value to send to the API from the resource data.
+	Expand func(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, diag.Diagnostics)
+
+	// Waiter optionally gets the configuration struct for the `WaitForState` functions, for settings that take
+	// a while to be reflected by the API after being written. e is the expected remote value.
+	Waiter func(c *ossign.ApiClient, e interface{}) resource.StateChangeConf
+
+	// Default is the value of the setting on a new account. It is written back when the resource is
+	// destroyed with `on_destroy = "reset_to_default"`. Its type must match the values returned by Get.
+	Default interface{}
+
+	// Create optionally writes v when the resource is created. Set is used when it is not provided.
+	Create func(c *ossign.ApiClient, v interface{}) *ossign.ApiError
+
+	// Delete optionally removes the setting from the account. It is used instead of Set when a value that
+	// doesn't pass Exists needs to be written back on destroy. Resources that provide it are reset to their
+	// default value on destroy unless `on_destroy` says otherwise.
+	Delete func(c *ossign.ApiClient) *ossign.ApiError
+
+	// CustomizeDiff optionally validates or customizes the plan of the resource.
+	CustomizeDiff schema.CustomizeDiffFunc
+
+	// SchemaVersion and StateUpgraders migrate the state of resources created with earlier versions of the schema.
+	SchemaVersion  int
+	StateUpgraders []schema.StateUpgrader
+
+	// OnDestroyNote is optionally appended to the description of `on_destroy`, e.g. to document the size of the
+	// snapshot taken with `restore_original`. It must start with a space.
+	OnDestroyNote string
+
+	// Exists optionally reports whether the remote value v denotes a configured setting. The resource is
+	// removed from the state when it doesn't.
+	Exists func(v interface{}) bool
+
+	// ConfiguredDrift limits the reports of changes made outside of Terraform to the attributes set in the
+	// configuration, for settings whose other attributes are owned by others. Terraform doesn't send the
+	// configuration when refreshing, so their paths are recorded in `configured_attributes` during the plan.
+	ConfiguredDrift bool
+
+	// MergeConfigured returns the remote value o with the attributes covered by the configured paths ps replaced by
+	// their value in v. With ConfiguredDrift, it is used on destroy so that only the configured attributes are
+	// reset or restored, leaving the attributes owned by others untouched.
+	MergeConfigured func(o interface{}, v interface{}, ps []interface{}) interface{}
+}
+
+const (
+	onDestroyKeep            = "keep"
+	onDestroyResetToDefault  = "reset_to_default"
+	onDestroyRestoreOriginal = "restore_original"
+)
+
+// Resource builds the schema.Resource of the setting.
+func (r *singletonResource) Resource() *schema.Resource {
+	s := make(map[string]*schema.Schema, len(r.Schema)+2)
+
+	for k, v := range r.Schema {
+		s[k] = v
+	}
+
+	od := onDestroyKeep
+	if r.Delete != nil {
+		od = onDestroyResetToDefault
+	}
+
+	s["on_destroy"] = &schema.Schema{
+		Description: fmt.Sprintf("What to do with the remote setting when the resource is destroyed: `%s` leaves the current value, "+
+			"`%s` writes back the default value of a new account and `%s` writes back the value the account had "+
+			"when the resource was created, or when `on_destroy` was changed to it for a resource already in the state (e.g. an "+
+			"imported one). Changing only `on_destroy` doesn't write the setting. Defaults to `%s`.%s",
+			onDestroyKeep, onDestroyResetToDefault, onDestroyRestoreOriginal, od, r.OnDestroyNote),
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          od,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{onDestroyKeep, onDestroyResetToDefault, onDestroyRestoreOriginal}, false)),
+	}
+
+	// The SDK does not expose the private state of resources, so the snapshot is kept as a sensitive computed
+	// attribute, which is hidden from the plans.
+	s["original_value"] = &schema.Schema{
+		Description: "Compressed snapshot of the remote setting taken when the resource was created with, or changed to, `on_destroy = \"restore_original\"`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+	}
+
+	if r.ConfiguredDrift {
+		s["configured_attributes"] = &schema.Schema{
+			Description: "Paths of the attributes set in the configuration. The changes made outside of Terraform are only reported for them.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+
+	return &schema.Resource{
+		Description: fmt.Sprintf("%s\n\n%s", r.Description, singletonDescriptionNote),
+
+		CreateContext: r.create,
+		ReadContext:   r.refresh,
+		UpdateContext: r.update,
+		DeleteContext: r.delete,
+
+		CustomizeDiff: r.customizeDiff,
+
+		Schema:         s,
+		SchemaVersion:  r.SchemaVersion,
+		StateUpgraders: r.StateUpgraders,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := importSingletonId(d, meta.(*providerMeta)); err != nil {
+					return nil, err
+				}
+
+				// Defaults are not applied on import
+				if err := d.Set("on_destroy", od); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}
+
+// upgradeSetBlockToList migrates the state of a resource where blocks with at most one item were changed
+// from TypeSet to TypeList. Both are stored as JSON arrays, so the state is kept as-is.
+func upgradeSetBlockToList(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return rawState, nil
+}
+
+// importSingletonIdAlias can be used as the import ID of singleton resources instead of the account ID.
+const importSingletonIdAlias = "account"
+
+// importSingletonId validates the ID given to `terraform import`. The ID of singleton resources is the ID of
+// the account that the provider is configured for, or the "account" alias.
+func importSingletonId(d *schema.ResourceData, m *providerMeta) error {
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErr.GetError()
+	}
+
+	if d.Id() != id && d.Id() != importSingletonIdAlias {
+		return fmt.Errorf("the import ID %q does not match the configured OneSpan Sign account, use %q or %q instead", d.Id(), id, importSingletonIdAlias)
+	}
+
+	d.SetId(id)
+
+	return nil
+}
+
+// customizeDiff ensures that a single instance of the resource manages the account during a run.
+//
+// Plans are computed once for every resource instance of the configuration, so a second plan of the same
+// resource type for the same account comes from another instance.
+func (r *singletonResource) customizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := claimSingletonDiff(d, meta, r.TypeName); err != nil {
+		return err
+	}
+
+	if r.ConfiguredDrift {
+		if err := r.setConfiguredAttributes(d); err != nil {
+			return err
+		}
+	}
+
+	// The snapshot is taken during the update when a resource already in the state is changed to restore_original
+	if d.Id() != "" && d.Get("on_destroy").(string) == onDestroyRestoreOriginal && d.Get("original_value").(string) == "" {
+		if err := d.SetNewComputed("original_value"); err != nil {
+			return err
+		}
+	}
+
+	if r.CustomizeDiff != nil {
+		return r.CustomizeDiff(ctx, d, meta)
+	}
+
+	return nil
+}
+
+// setConfiguredAttributes plans the paths of the attributes set in the configuration, see ConfiguredDrift.
+func (r *singletonResource) setConfiguredAttributes(d *schema.ResourceDiff) error {
+	cfg := d.GetRawConfig()
+	if !cfg.IsKnown() || cfg.IsNull() {
+		return nil
+	}
+
+	var ps []string
+
+	for k := range r.Schema {
+		ps = appendConfiguredPaths(ps, k, cfg.GetAttr(k))
+	}
+
+	sort.Strings(ps)
+
+	n := make([]interface{}, len(ps))
+	for i, p := range ps {
+		n[i] = p
+	}
+
+	if reflect.DeepEqual(d.Get("configured_attributes"), n) {
+		return nil
+	}
+
+	return d.SetNew("configured_attributes", n)
+}
+
+// claimSingletonDiff claims the account for the planned instance d of the singleton resource type t. It returns
+// an error when another instance of the type claimed the account during the run.
+func claimSingletonDiff(d *schema.ResourceDiff, meta interface{}, t string) error {
+	// The provider is not configured yet when its configuration depends on other resources
+	m, ok := meta.(*providerMeta)
+	if !ok || m == nil {
+		return nil
+	}
+
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErr.GetError()
+	}
+
+	desc := describeSingletonInstance(d)
+
+	if o, ok := m.claimSingleton(t, id, desc); !ok {
+		return singletonConflictError(t, id, desc, o)
+	}
+
+	return nil
+}
+
+// describeSingletonInstance describes the planned instance d of a singleton resource. Terraform doesn't send the
+// addresses of the resources to the providers, so the instance is described by whether it is already in the state
+// and by its configured attributes.
+func describeSingletonInstance(d *schema.ResourceDiff) string {
+	desc := "a new instance"
+	if d.Id() != "" {
+		desc = "an instance already in the state"
+	}
+
+	var ks []string
+
+	if cfg := d.GetRawConfig(); cfg.IsKnown() && !cfg.IsNull() {
+		for k, v := range cfg.AsValueMap() {
+			if v.IsNull() || (v.IsKnown() && v.CanIterateElements() && v.LengthInt() == 0) {
+				continue
+			}
+
+			ks = append(ks, fmt.Sprintf("%q", k))
+		}
+	}
+
+	if len(ks) == 0 {
+		return desc + " without configured attributes"
+	}
+
+	sort.Strings(ks)
+
+	return fmt.Sprintf("%s configuring %s", desc, strings.Join(ks, ", "))
+}
+
+// singletonConflictError is the error of the instance desc of the singleton resource type t planned after the
+// instance o for the account a. Terraform shows the address of the instance desc along with the error.
+func singletonConflictError(t string, a string, desc string, o string) error {
+	return fmt.Errorf("%s is a singleton, but it is declared more than once for the OneSpan Sign account %q. "+
+		"This instance (%s) conflicts with another instance of %s planned earlier in the run (%s). "+
+		"Providers are not given the addresses of the resources: look for the other %s resource in the configuration "+
+		"and its modules, and remove all but one of them",
+		t, a, desc, t, o, t)
+}
+
+// apiErrorDiags converts an API error to diagnostics.
+func apiErrorDiags(err *ossign.ApiError) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Summary,
+			Detail:   err.Detail,
+		},
+	}
+}
+
+// write sends the value built from the resource data with the function w, then waits for the API
+// to reflect it.
+func (r *singletonResource) write(ctx context.Context, d *schema.ResourceData, meta interface{}, w func(*ossign.ApiClient, interface{}) *ossign.ApiError) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	v, diags := r.Expand(ctx, d, meta)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if apiErr := w(c, v); apiErr != nil {
+		return append(diags, apiErrorDiags(apiErr)...)
+	}
+
+	if r.Waiter != nil {
+		tflog.Trace(ctx, fmt.Sprintf("waiting for the account's %s resource to be updated...", r.Name))
+
+		scc := r.Waiter(c, v)
+		if _, err := scc.WaitForStateContext(ctx); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated the account's %s resource", r.Name))
+
+	return diags
+}
+
+// snapshot keeps the current remote value of the setting in `original_value`, so that it can be written back
+// by `on_destroy = "restore_original"`.
+func (r *singletonResource) snapshot(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	o, apiErr := r.Get(meta.(*providerMeta).client)
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	b, err := encodeSnapshot(o)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("original_value", b); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func (r *singletonResource) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.Get("on_destroy").(string) == onDestroyRestoreOriginal {
+		if diags := r.snapshot(d, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	w := r.Create
+
+	if w == nil {
+		w = r.Set
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "updating (replacing) resource instead of creating",
+			Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
+		})
+	}
+
+	diags = append(diags, r.write(ctx, d, meta, w)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	id, apiErr := meta.(*providerMeta).getAccountId()
+	if apiErr != nil {
+		return append(diags, apiErrorDiags(apiErr)...)
+	}
+
+	d.SetId(id)
+
+	return append(diags, r.read(ctx, d, meta)...)
+}
+
+func (r *singletonResource) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.client
+
+	var diags diag.Diagnostics
+
+	// Earlier versions of the provider used the client ID as the resource ID, which doesn't match the configured
+	// client ID anymore once the client app is rotated. The account has a single instance of the setting, so any
+	// other ID is moved to the account ID.
+	id, apiErr := m.getAccountId()
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if d.Id() != id {
+		tflog.Info(ctx, fmt.Sprintf("migrating the ID of the account's %s resource to the account ID", r.Name), map[string]interface{}{
+			"previous_id": d.Id(),
+		})
+		d.SetId(id)
+	}
+
+	v, apiErr := r.Get(c)
+
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if r.Exists != nil && !r.Exists(v) {
+		d.SetId("")
+		return diags
+	}
+
+	if err := r.Flatten(d, v, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// refresh reads the remote value of the setting and reports the attributes that were changed outside
+// of Terraform since the last time it was read.
+func (r *singletonResource) refresh(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	o, ks := getDriftValues(d, r.Schema, nil)
+
+	diags := r.read(ctx, d, meta)
+
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	n, _ := getDriftValues(d, r.Schema, ks)
+
+	// States written before the configured attributes were recorded report the changes of all the attributes
+	if st := d.State(); r.ConfiguredDrift && st != nil {
+		if _, ok := st.Attributes["configured_attributes.#"]; ok {
+			ps := d.Get("configured_attributes").([]interface{})
+			filterConfiguredDrift(o, ps)
+			filterConfiguredDrift(n, ps)
+		}
+	}
+
+	return append(diags, getDriftDiags(r.Name, o, n, meta.(*providerMeta).failOnDrift)...)
+}
+
+func (r *singletonResource) update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Resources imported or created with another on_destroy don't have a snapshot yet, the value is taken before
+	// the new one is written
+	if o, _ := d.GetChange("original_value"); d.Get("on_destroy").(string) == onDestroyRestoreOriginal && o.(string) == "" {
+		if diags := r.snapshot(d, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	// Only the attributes of the resource itself changed, the setting is unchanged
+	if !d.HasChangesExcept("on_destroy", "original_value", "configured_attributes") {
+		return r.read(ctx, d, meta)
+	}
+
+	diags := r.write(ctx, d, meta, r.Set)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, r.read(ctx, d, meta)...)
+}
+
+func (r *singletonResource) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	var diags diag.Diagnostics
+	var v interface{}
+
+	switch d.Get("on_destroy").(string) {
+	case onDestroyResetToDefault:
+		v = r.Default
+
+	case onDestroyRestoreOriginal:
+		o := d.Get("original_value").(string)
+
+		if o == "" {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "no deletion will take place",
+				Detail:   "No snapshot of the original value was taken when this resource was created. The current value is kept on the account.",
+			})
+		}
+
+		p := reflect.New(reflect.TypeOf(r.Default))
+		if err := decodeSnapshot(o, p.Interface()); err != nil {
+			return diag.FromErr(err)
+		}
+		v = p.Elem().Interface()
+
+	default:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "no deletion will take place",
+			Detail:   "This resource is a singleton. It only supports retrieval or replacement operations.",
+		})
+	}
+
+	// States written before the configured attributes were recorded write back the whole value
+	if st := d.State(); r.ConfiguredDrift && r.MergeConfigured != nil && st != nil {
+		if _, ok := st.Attributes["configured_attributes.#"]; ok {
+			o, apiErr := r.Get(c)
+			if apiErr != nil {
+				return apiErrorDiags(apiErr)
+			}
+
+			v = r.MergeConfigured(o, v, d.Get("configured_attributes").([]interface{}))
+		}
+	}
+
+	var apiErr *ossign.ApiError
+
+	if r.Delete != nil && r.Exists != nil && !r.Exists(v) {
+		apiErr = r.Delete(c)
+	} else {
+		apiErr = r.Set(c, v)
+	}
+
+	if apiErr != nil {
+		return apiErrorDiags(apiErr)
+	}
+
+	if r.Waiter != nil {
+		tflog.Trace(ctx, fmt.Sprintf("waiting for the account's %s resource to be reverted...", r.Name))
+
+		scc := r.Waiter(c, v)
+		if _, err := scc.WaitForStateContext(ctx); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("reverted the account's %s resource", r.Name))
+
+	d.SetId("")
+
+	return diags
+}
+
+// encodeSnapshot encodes the remote value v for the `original_value` attribute, as gzipped JSON encoded in base 64.
+// Snapshots of settings holding images (e.g. the signing logos) would otherwise take up to several MB in the state.
+func encodeSnapshot(v interface{}) (string, error) {
+	var b bytes.Buffer
+
+	w := base64.NewEncoder(base64.StdEncoding, &b)
+	z := gzip.NewWriter(w)
+
+	if err := json.NewEncoder(z).Encode(v); err != nil {
+		return "", err
+	}
+
+	if err := z.Close(); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// decodeSnapshot decodes the `original_value` attribute s into the value pointed to by v. Snapshots taken by
+// earlier versions of the provider are plain JSON.
+func decodeSnapshot(s string, v interface{}) error {
+	if json.Valid([]byte(s)) {
+		return json.Unmarshal([]byte(s), v)
+	}
+
+	z, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(s)))
+	if err != nil {
+		return fmt.Errorf("unable to decode the snapshot of the original value: %w", err)
+	}
+	defer z.Close()
+
+	return json.NewDecoder(z).Decode(v)
+}
+
+// DataSource builds a read-only schema.Resource for the setting, so that it can be read by configurations
+// that do not manage it.
+func (r *singletonResource) DataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: fmt.Sprintf("Retrieves the %s of the OneSpan Sign account.", r.Name),
+
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			m := meta.(*providerMeta)
+
+			v, apiErr := r.Get(m.client)
+			if apiErr != nil {
+				return apiErrorDiags(apiErr)
+			}
+
+			if err := r.Flatten(d, v, meta); err != nil {
+				return diag.FromErr(err)
+			}
+
+			id, apiErr := m.getAccountId()
+			if apiErr != nil {
+				return apiErrorDiags(apiErr)
+			}
+
+			d.SetId(id)
+
+			return nil
+		},
+
+		Schema: computedSchema(r.Schema),
+	}
+}
+
+// computedSchema returns a copy of the schema s where all the attributes are computed.
+func computedSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	r := make(map[string]*schema.Schema, len(s))
+
+	for k, v := range s {
+		c := &schema.Schema{
+			Type:        v.Type,
+			Description: v.Description,
+			Sensitive:   v.Sensitive,
+			Computed:    true,
+		}
+
+		switch e := v.Elem.(type) {
+		case *schema.Resource:
+			c.Elem = &schema.Resource{
+				Schema: computedSchema(e.Schema),
+			}
+
+		case *schema.Schema:
+			c.Elem = &schema.Schema{
+				Type: e.Type,
+			}
+		}
+
+		r[k] = c
+	}
+
+	return r
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/getbreathelife/terraform-provider-onespansign/pkg/ossign"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	logos := make([]ossign.SigningLogo, 0, len(ossign.Languages))
+	for _, l := range ossign.Languages {
+		logos = append(logos, ossign.SigningLogo{Language: string(l), Image: testImg})
+	}
+
+	s, err := encodeSnapshot(logos)
+	assert.NoError(t, err)
+
+	var r []ossign.SigningLogo
+	assert.NoError(t, decodeSnapshot(s, &r))
+	assert.Equal(t, logos, r)
+
+	// The snapshot is smaller than the JSON of the images
+	b, _ := json.Marshal(logos)
+	assert.Less(t, len(s), len(b)/4)
+
+	// Snapshots taken by earlier versions are plain JSON
+	r = nil
+	assert.NoError(t, decodeSnapshot(string(b), &r))
+	assert.Equal(t, logos, r)
+
+	assert.Error(t, decodeSnapshot("not a snapshot", &r))
+}
+
+func TestClaimSingletonDiff(t *testing.T) {
+	m := newProviderMeta(nil)
+	m.accountId = "account1"
+
+	theme := map[string]interface{}{
+		"theme": []interface{}{
+			map[string]interface{}{
+				"name":                      "default",
+				"primary":                   "#1A4F9C",
+				"success":                   "#2E7D32",
+				"warning":                   "#F2C200",
+				"error":                     "#C62828",
+				"info":                      "#1565C0",
+				"signature_button":          "#1A4F9C",
+				"optional_signature_button": "#5C6BC0",
+			},
+		},
+	}
+
+	_, diags := testPlanResourceChange(t, m, "onespansign_account_signing_themes", nil, theme)
+	assert.Empty(t, diags)
+
+	theme["on_destroy"] = "keep"
+	_, diags = testPlanResourceChange(t, m, "onespansign_account_signing_themes", map[string]interface{}{"id": "account1"}, theme)
+
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Summary, `This instance (an instance already in the state configuring "on_destroy", "theme")`)
+		assert.Contains(t, diags[0].Summary, `planned earlier in the run (a new instance configuring "theme")`)
+	}
+}
+
+func TestSingletonIdMigration(t *testing.T) {
+	m := newProviderMeta(testApiClient(t, map[string]interface{}{
+		"/api/dataRetentionSettings/expiryTimeConfiguration": map[string]interface{}{
+			"remainingDays":        30,
+			"maximumRemainingDays": 60,
+		},
+	}))
+	m.accountId = "account1"
+
+	r := resourceExpiryTimeConfig()
+
+	// The ID of the state is the client ID of a client app that was rotated since
+	d := r.Data(&terraform.InstanceState{
+		ID: "rotated-client-id",
+		Attributes: map[string]string{
+			"id":         "rotated-client-id",
+			"on_destroy": "keep",
+			"default":    "30",
+			"maximum":    "60",
+		},
+	})
+
+	assert.Empty(t, r.ReadContext(context.Background(), d, m))
+	assert.Equal(t, "account1", d.Id())
+}
+
+func TestSingletonOnDestroyChange(t *testing.T) {
+	etc := map[string]interface{}{
+		"remainingDays":        30,
+		"maximumRemainingDays": 60,
+	}
+
+	res := map[string]interface{}{
+		"/api/dataRetentionSettings/dataManagementPolicy": map[string]interface{}{
+			"transactionRetention": map[string]interface{}{"sent": 90},
+		},
+		"/api/dataRetentionSettings/expiryTimeConfiguration": etc,
+	}
+
+	m := newProviderMeta(testApiClient(t, res))
+	m.accountId = "account1"
+
+	// An imported resource doesn't have a snapshot of the original value
+	prior := map[string]interface{}{
+		"id":         "account1",
+		"on_destroy": "keep",
+		"default":    30,
+		"maximum":    60,
+	}
+
+	config := map[string]interface{}{
+		"on_destroy": "restore_original",
+		"default":    30,
+		"maximum":    60,
+	}
+
+	p, diags := testPlanResourceChange(t, m, "onespansign_expiry_time_config", prior, config)
+	assert.Empty(t, diags)
+	assert.False(t, p.GetAttr("original_value").IsKnown())
+
+	s, diags := testApplyResourceChange(t, m, "onespansign_expiry_time_config", prior, p, config)
+	assert.Empty(t, diags)
+
+	// The setting isn't written again
+	assert.Equal(t, etc, res["/api/dataRetentionSettings/expiryTimeConfiguration"])
+
+	var o ossign.ExpiryTimeConfiguration
+	if assert.NoError(t, decodeSnapshot(s.GetAttr("original_value").AsString(), &o)) {
+		assert.Equal(t, json.Number("30"), o.Default)
+		assert.Equal(t, json.Number("60"), o.Maximum)
+	}
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
 	"github.com/getbreathelife/terraform-provider-onespansign/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -32,11 +34,22 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		ProviderAddr: "registry.terraform.io/breathelife/onespansign",
-		Debug:        debugMode,
-		ProviderFunc: provider.New(version),
+	ctx := context.Background()
+
+	// The provider is served by both the SDKv2 and the plugin framework implementations, see provider.NewMuxServer
+	server, err := provider.NewMuxServer(ctx, version)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	plugin.Serve(opts)
+	var serveOpts []tf5server.ServeOpt
+
+	if debugMode {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/breathelife/onespansign", server, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
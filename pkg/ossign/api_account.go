@@ -0,0 +1,109 @@
+package ossign
+
+import (
+	"net/http"
+)
+
+type AccountCompany struct {
+	// ID of the company
+	Id string `json:"id"`
+
+	// Name of the company
+	Name string `json:"name"`
+}
+
+type AccountPlan struct {
+	// ID of the plan
+	Id string `json:"id"`
+
+	// Name of the plan
+	Name string `json:"name"`
+
+	// Billing cycle of the plan (e.g. MONTH, YEAR)
+	Cycle string `json:"cycle"`
+
+	// Features enabled by the plan
+	Features []string `json:"features"`
+}
+
+type AccountLicense struct {
+	// Status of the license (e.g. ACTIVE, CANCELLED)
+	Status string `json:"status"`
+
+	// Plan the license was purchased for
+	Plan AccountPlan `json:"plan"`
+
+	// Creation date of the license
+	Created string `json:"created"`
+
+	// Date until which the license has been paid for
+	PaidUntil string `json:"paidUntil"`
+}
+
+type Account struct {
+	// ID of the account
+	Id string `json:"id"`
+
+	// Name of the account
+	Name string `json:"name"`
+
+	// Company the account belongs to
+	Company AccountCompany `json:"company"`
+
+	// ID of the user owning the account
+	Owner string `json:"owner"`
+
+	// Creation date of the account
+	Created string `json:"created"`
+
+	// Licenses of the account
+	Licenses []AccountLicense `json:"licenses"`
+}
+
+// GetAccount retrieves the account the client app belongs to.
+//
+// https://community.onespan.com/products/onespan-sign/sandbox#/Account/api.account.get
+func (c *ApiClient) GetAccount() (*Account, *ApiError) {
+	res, err := c.makeApiRequest("GET", "/api/account", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, getApiError(res)
+	}
+
+	var jsonResp Account
+
+	if err := jsonDecode(res.Body, &jsonResp); err != nil {
+		return nil, &ApiError{
+			Summary: "unable to unmarshal the API response",
+			Detail:  err.Error(),
+		}
+	}
+
+	return &jsonResp, nil
+}
+
+// Features returns the features enabled by the active licenses of the account.
+func (a *Account) Features() []string {
+	var r []string
+
+	seen := make(map[string]bool)
+
+	for _, l := range a.Licenses {
+		if l.Status != "ACTIVE" {
+			continue
+		}
+
+		for _, f := range l.Plan.Features {
+			if !seen[f] {
+				seen[f] = true
+				r = append(r, f)
+			}
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,179 @@
+package ossign
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SenderType is the role of a sender in the account.
+type SenderType string
+
+const (
+	SenderTypeRegular SenderType = "REGULAR"
+	SenderTypeManager SenderType = "MANAGER"
+)
+
+// SenderStatus is the status of a sender's membership to the account.
+type SenderStatus string
+
+const (
+	// SenderStatusInvited is the status of the senders that haven't accepted the invitation to the account yet.
+	SenderStatusInvited SenderStatus = "INVITED"
+
+	// SenderStatusActive is the status of the senders that can use the account.
+	SenderStatusActive SenderStatus = "ACTIVE"
+
+	// SenderStatusLocked is the status of the deactivated senders, which can't log in nor send transactions.
+	SenderStatusLocked SenderStatus = "LOCKED"
+)
+
+type Sender struct {
+	// ID of the sender, assigned by the API
+	Id string `json:"id,omitempty"`
+
+	// Email address of the sender, it can't be changed once the sender is created
+	Email string `json:"email,omitempty"`
+
+	// First name of the sender
+	FirstName string `json:"firstName"`
+
+	// Last name of the sender
+	LastName string `json:"lastName"`
+
+	// Company of the sender
+	Company string `json:"company"`
+
+	// Title of the sender
+	Title string `json:"title"`
+
+	// Phone number of the sender
+	Phone string `json:"phone"`
+
+	// Language of the sender's emails and user interface
+	Language string `json:"language,omitempty"`
+
+	// Role of the sender in the account
+	Type SenderType `json:"type,omitempty"`
+
+	// Status of the sender's membership to the account
+	Status SenderStatus `json:"status,omitempty"`
+
+	// Creation date of the sender
+	Created string `json:"created,omitempty"`
+
+	// Last update date of the sender
+	Updated string `json:"updated,omitempty"`
+}
+
+// senderPath returns the path of the sender with the ID id.
+func senderPath(id string) string {
+	return fmt.Sprintf("/api/account/senders/%s", id)
+}
+
+// decodeSender decodes the sender of the API response res.
+func decodeSender(res *http.Response) (*Sender, *ApiError) {
+	var jsonResp Sender
+
+	if err := jsonDecode(res.Body, &jsonResp); err != nil {
+		return nil, &ApiError{
+			Summary: "unable to unmarshal the API response",
+			Detail:  err.Error(),
+		}
+	}
+
+	return &jsonResp, nil
+}
+
+// CreateSender Adds a sender to the account. Senders created with the INVITED status, the default, receive an
+// email inviting them to the account. Senders created with the ACTIVE status can use the account right away.
+//
+// https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Senders/api.account.senders.post
+func (c *ApiClient) CreateSender(s Sender) (*Sender, *ApiError) {
+	body, err := json.Marshal(s)
+
+	if err != nil {
+		return nil, &ApiError{
+			Summary: "unable to marshal the request body",
+			Detail:  err.Error(),
+		}
+	}
+
+	res, apiErr := c.makeApiRequest("POST", "/api/account/senders", bytes.NewBuffer(body))
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, getApiError(res)
+	}
+
+	return decodeSender(res)
+}
+
+// GetSender Retrieves a sender of the account.
+//
+// https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Senders/api.account.senders._senderId.get
+func (c *ApiClient) GetSender(id string) (*Sender, *ApiError) {
+	res, err := c.makeApiRequest("GET", senderPath(id), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, getApiError(res)
+	}
+
+	return decodeSender(res)
+}
+
+// UpdateSender Updates a sender of the account. The email address of a sender can't be updated.
+//
+// https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Senders/api.account.senders._senderId.post
+func (c *ApiClient) UpdateSender(id string, s Sender) *ApiError {
+	// The ID and the email address are read-only
+	s.Id = ""
+	s.Email = ""
+
+	body, err := json.Marshal(s)
+
+	if err != nil {
+		return &ApiError{
+			Summary: "unable to marshal the request body",
+			Detail:  err.Error(),
+		}
+	}
+
+	res, apiErr := c.makeApiRequest("POST", senderPath(id), bytes.NewBuffer(body))
+
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return getApiError(res)
+	}
+
+	return nil
+}
+
+// DeleteSender Deletes a sender from the account. The senders that still own transactions can't be deleted, they
+// can be deactivated by updating their status to LOCKED instead.
+//
+// https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Senders/api.account.senders._senderId.delete
+func (c *ApiClient) DeleteSender(id string) *ApiError {
+	res, err := c.makeApiRequest("DELETE", senderPath(id), nil)
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return getApiError(res)
+	}
+
+	return nil
+}
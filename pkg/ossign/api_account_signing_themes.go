@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"reflect"
+	"strings"
 )
 
 type SigningTheme struct {
@@ -27,24 +29,129 @@ type SigningTheme struct {
 
 	// Optional signature button color hex value
 	OptionalSignatureButton string `json:"optionalSignatureButton"`
+
+	// Sections are the other sections of the theme (e.g. fonts), keyed by name, as returned by the API.
+	// They are sent back untouched.
+	Sections map[string]json.RawMessage `json:"sections,omitempty"`
+}
+
+// NamedSigningTheme is a signing theme of the account along with its name.
+type NamedSigningTheme struct {
+	Name  string
+	Theme SigningTheme
+}
+
+// SigningThemes are the signing themes of the account, in the order returned by the API. The Signing Ceremony
+// uses the first theme, the API doesn't document whether it keeps the order the themes were written in.
+//
+// They are marshalled to a JSON object of the themes keyed by their name, in order.
+type SigningThemes []NamedSigningTheme
+
+// Names returns the names of the themes, in order.
+func (t SigningThemes) Names() []string {
+	r := make([]string, len(t))
+
+	for i, v := range t {
+		r[i] = v.Name
+	}
+
+	return r
+}
+
+// Get returns the theme named n, and whether it exists.
+func (t SigningThemes) Get(n string) (SigningTheme, bool) {
+	for _, v := range t {
+		if v.Name == n {
+			return v.Theme, true
+		}
+	}
+
+	return SigningTheme{}, false
 }
 
-func buildJsonRequestPayload(t map[string]SigningTheme) ([]byte, error) {
-	m := make(map[string]map[string]SigningTheme, len(t))
+func (t SigningThemes) MarshalJSON() ([]byte, error) {
+	return jsonEncodeObject(t.Names(), func(k string) interface{} {
+		v, _ := t.Get(k)
+		return v
+	})
+}
+
+func (t *SigningThemes) UnmarshalJSON(b []byte) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	r := SigningThemes{}
 
-	for k, v := range t {
-		m[k] = map[string]SigningTheme{
-			"color": v,
+	err := jsonDecodeObject(d, func(k string) error {
+		var v SigningTheme
+		if err := d.Decode(&v); err != nil {
+			return err
 		}
+
+		r = append(r, NamedSigningTheme{Name: k, Theme: v})
+		return nil
+	})
+
+	if err != nil {
+		return err
 	}
 
-	return json.Marshal(m)
+	*t = r
+	return nil
+}
+
+// signingThemeColorSection is the name of the section of the colors in the API payloads.
+const signingThemeColorSection = "color"
+
+// apiSections returns the sections of the theme t in the API payloads, keyed by name.
+func (t SigningTheme) apiSections() map[string]interface{} {
+	r := make(map[string]interface{}, len(t.Sections)+1)
+
+	for k, v := range t.Sections {
+		r[k] = v
+	}
+
+	c := t
+	c.Sections = nil
+	r[signingThemeColorSection] = c
+
+	return r
+}
+
+// decodeSigningTheme decodes a theme of the API payloads from d.
+func decodeSigningTheme(d *json.Decoder) (SigningTheme, error) {
+	var t SigningTheme
+	var s map[string]json.RawMessage
+
+	if err := d.Decode(&s); err != nil {
+		return t, err
+	}
+
+	if c, ok := s[signingThemeColorSection]; ok {
+		if err := json.Unmarshal(c, &t); err != nil {
+			return t, err
+		}
+
+		delete(s, signingThemeColorSection)
+	}
+
+	t.Sections = nil
+	if len(s) > 0 {
+		t.Sections = s
+	}
+
+	return t, nil
+}
+
+func buildJsonRequestPayload(t SigningThemes) ([]byte, error) {
+	return jsonEncodeObject(t.Names(), func(k string) interface{} {
+		v, _ := t.Get(k)
+		return v.apiSections()
+	})
 }
 
 // CreateAccountSigningThemes creates customized signing themes on the account.
 //
 // https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Signing%20Themes/api.account.signingThemes.post
-func (c *ApiClient) CreateAccountSigningThemes(t map[string]SigningTheme) *ApiError {
+func (c *ApiClient) CreateAccountSigningThemes(t SigningThemes) *ApiError {
 	body, err := buildJsonRequestPayload(t)
 
 	if err != nil {
@@ -70,7 +177,9 @@ func (c *ApiClient) CreateAccountSigningThemes(t map[string]SigningTheme) *ApiEr
 // GetAccountSigningThemes retrieves the customized signing themes on the account.
 //
 // https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Signing%20Themes/api.account.signingThemes.get
-func (c *ApiClient) GetAccountSigningThemes() (map[string]SigningTheme, *ApiError) {
+//
+// The themes are returned in the order of the API response.
+func (c *ApiClient) GetAccountSigningThemes() (SigningThemes, *ApiError) {
 	res, err := c.makeApiRequest("GET", "/api/account/signingThemes", nil)
 
 	if err != nil {
@@ -81,28 +190,31 @@ func (c *ApiClient) GetAccountSigningThemes() (map[string]SigningTheme, *ApiErro
 		return nil, getApiError(res)
 	}
 
-	var jsonResp map[string]map[string]SigningTheme
+	d := json.NewDecoder(res.Body)
+	r := SigningThemes{}
 
-	if err := jsonDecode(res.Body, &jsonResp); err != nil {
+	if err := jsonDecodeObject(d, func(k string) error {
+		v, err := decodeSigningTheme(d)
+		if err != nil {
+			return err
+		}
+
+		r = append(r, NamedSigningTheme{Name: k, Theme: v})
+		return nil
+	}); err != nil {
 		return nil, &ApiError{
 			Summary: "unable to unmarshal the API response",
 			Detail:  err.Error(),
 		}
 	}
 
-	r := make(map[string]SigningTheme, len(jsonResp))
-
-	for k, v := range jsonResp {
-		r[k] = v["color"]
-	}
-
 	return r, nil
 }
 
 // UpdateAccountSigningThemes updates the customized signing themes on the account.
 //
 // https://community.onespan.com/products/onespan-sign/sandbox#/Account%20Signing%20Themes/api.account.signingThemes.put
-func (c *ApiClient) UpdateAccountSigningThemes(t map[string]SigningTheme) *ApiError {
+func (c *ApiClient) UpdateAccountSigningThemes(t SigningThemes) *ApiError {
 	body, err := buildJsonRequestPayload(t)
 
 	if err != nil {
@@ -142,12 +254,40 @@ func (c *ApiClient) DeleteAccountSigningThemes() *ApiError {
 	return nil
 }
 
+// Equal reports whether the themes l and r have the same colors and sections. Color hex codes are compared
+// case-insensitively, and sections are compared as JSON values.
 func (l SigningTheme) Equal(r SigningTheme) bool {
-	return l.Primary == r.Primary &&
-		l.Success == r.Success &&
-		l.Warning == r.Warning &&
-		l.Error == r.Error &&
-		l.Info == r.Info &&
-		l.SignatureButton == r.SignatureButton &&
-		l.OptionalSignatureButton == r.OptionalSignatureButton
+	if len(l.Sections) != len(r.Sections) {
+		return false
+	}
+
+	for k, lv := range l.Sections {
+		rv, ok := r.Sections[k]
+		if !ok || !jsonEqual(lv, rv) {
+			return false
+		}
+	}
+
+	return strings.EqualFold(l.Primary, r.Primary) &&
+		strings.EqualFold(l.Success, r.Success) &&
+		strings.EqualFold(l.Warning, r.Warning) &&
+		strings.EqualFold(l.Error, r.Error) &&
+		strings.EqualFold(l.Info, r.Info) &&
+		strings.EqualFold(l.SignatureButton, r.SignatureButton) &&
+		strings.EqualFold(l.OptionalSignatureButton, r.OptionalSignatureButton)
+}
+
+// jsonEqual reports whether the JSON documents l and r hold the same value.
+func jsonEqual(l json.RawMessage, r json.RawMessage) bool {
+	var lv, rv interface{}
+
+	if err := json.Unmarshal(l, &lv); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(r, &rv); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(lv, rv)
 }
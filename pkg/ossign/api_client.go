@@ -195,3 +195,71 @@ func getApiError(res *http.Response) *ApiError {
 func (e *ApiError) GetError() error {
 	return fmt.Errorf("an API error occurred: '%s'\n%s", e.Summary, e.Detail)
 }
+
+// IsNotFound reports whether the error is a 404 response of the API, e.g. when the requested resource was deleted.
+func (e *ApiError) IsNotFound() bool {
+	return e.HttpResponse != nil && e.HttpResponse.StatusCode == http.StatusNotFound
+}
+
+// jsonDecodeObject decodes the JSON object read from d member by member, keeping their order. f is called with the
+// key of every member and must decode its value from d. A null value is decoded as an empty object.
+func jsonDecodeObject(d *json.Decoder, f func(k string) error) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+
+	for d.More() {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		if err := f(tok.(string)); err != nil {
+			return err
+		}
+	}
+
+	_, err = d.Token()
+	return err
+}
+
+// jsonEncodeObject encodes the members of a JSON object, keeping the order of ks. f returns the value of the
+// member with the key k.
+func jsonEncodeObject(ks []string, f func(k string) interface{}) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	for i, k := range ks {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		vb, err := json.Marshal(f(k))
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
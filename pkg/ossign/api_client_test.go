@@ -78,6 +78,114 @@ func setupTestServer(tsc *testServerConfig) (*testhelpers.HttpRequestHistory, *h
 					w.WriteHeader(http.StatusNotFound)
 				}
 
+			case "/api/account/signingThemes":
+				switch r.Method {
+				case "GET":
+					// The themes are not in alphabetical order, to check that their order is kept
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					io.WriteString(w, `{
+						"spring": {"color": {"primary": "#2E7D32", "success": "#2E7D32", "warning": "#F9A825", "error": "#C62828", "info": "#1565C0", "signatureButton": "#2E7D32", "optionalSignatureButton": "#A5D6A7"}},
+						"default": {"color": {"primary": "#1565C0", "success": "#2E7D32", "warning": "#F9A825", "error": "#C62828", "info": "#1565C0", "signatureButton": "#1565C0", "optionalSignatureButton": "#90CAF9"}, "font": {"family": "Roboto", "size": 14}, "background": {"color": "#FAFAFA"}},
+						"autumn": {"color": {"primary": "#BF360C", "success": "#2E7D32", "warning": "#F9A825", "error": "#C62828", "info": "#1565C0", "signatureButton": "#BF360C", "optionalSignatureButton": "#FFAB91"}}
+					}`)
+
+				case "PUT":
+					w.WriteHeader(http.StatusOK)
+
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+
+			case "/api/account":
+				switch r.Method {
+				case "GET":
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"id":   "account-id",
+						"name": "Breathe Life",
+						"company": map[string]interface{}{
+							"id":   "company-id",
+							"name": "Breathe Life Inc.",
+						},
+						"owner":   "owner-id",
+						"created": "2022-01-01T00:00:00Z",
+						"licenses": []map[string]interface{}{
+							{
+								"status": "ACTIVE",
+								"plan": map[string]interface{}{
+									"id":       "plan-id",
+									"name":     "Enterprise",
+									"cycle":    "YEAR",
+									"features": []string{"SIGNING_THEMES", "SIGNING_LOGOS"},
+								},
+								"created":   "2022-01-01T00:00:00Z",
+								"paidUntil": "2023-01-01T00:00:00Z",
+							},
+							{
+								"status": "CANCELLED",
+								"plan": map[string]interface{}{
+									"id":       "plan-id-2",
+									"name":     "Professional",
+									"features": []string{"BULK_SEND"},
+								},
+							},
+						},
+					})
+
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+
+			case "/api/account/senders":
+				switch r.Method {
+				case "POST":
+					var s map[string]interface{}
+					if err := json.Unmarshal(b, &s); err != nil {
+						panic(err)
+					}
+
+					s["id"] = "sender-id"
+					if _, ok := s["status"]; !ok {
+						s["status"] = "INVITED"
+					}
+
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(s)
+
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+
+			case "/api/account/senders/sender-id":
+				switch r.Method {
+				case "GET":
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(map[string]interface{}{
+						"id":        "sender-id",
+						"email":     "jane.doe@example.com",
+						"firstName": "Jane",
+						"lastName":  "Doe",
+						"company":   "Breathe Life Inc.",
+						"title":     "Underwriter",
+						"phone":     "+1 514 555 0100",
+						"language":  "fr",
+						"type":      "MANAGER",
+						"status":    "ACTIVE",
+						"created":   "2022-01-01T00:00:00Z",
+						"updated":   "2022-01-02T00:00:00Z",
+					})
+
+				case "POST", "DELETE":
+					w.WriteHeader(http.StatusOK)
+
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+
 			case "/apitoken/clientApp/accessToken":
 				switch r.Method {
 				case "POST":
@@ -203,3 +311,321 @@ func TestGetAuthTokenRefresh(t *testing.T) {
 	r = h.Stack[3]
 	assert.Equal(t, fmt.Sprintf("Bearer %s", token2), r.Request.Header.Get("Authorization"))
 }
+
+func TestGetAccount(t *testing.T) {
+	_, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	a, apiErr := c.GetAccount()
+
+	assert.Nil(t, apiErr)
+	assert.Equal(t, "account-id", a.Id)
+	assert.Equal(t, "Breathe Life", a.Name)
+	assert.Equal(t, "Breathe Life Inc.", a.Company.Name)
+	assert.Equal(t, "owner-id", a.Owner)
+	assert.Equal(t, 2, len(a.Licenses))
+	assert.Equal(t, "Enterprise", a.Licenses[0].Plan.Name)
+	assert.Equal(t, []string{"SIGNING_THEMES", "SIGNING_LOGOS"}, a.Features())
+}
+
+func TestGetAccountSigningThemes(t *testing.T) {
+	_, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	for i := 0; i < 10; i++ {
+		th, apiErr := c.GetAccountSigningThemes()
+
+		assert.Nil(t, apiErr)
+		assert.Equal(t, []string{"spring", "default", "autumn"}, th.Names())
+		assert.Equal(t, "#2E7D32", th[0].Theme.Primary)
+		assert.Equal(t, "#90CAF9", th[1].Theme.OptionalSignatureButton)
+		assert.Nil(t, th[0].Theme.Sections)
+		assert.JSONEq(t, `{"family": "Roboto", "size": 14}`, string(th[1].Theme.Sections["font"]))
+		assert.JSONEq(t, `{"color": "#FAFAFA"}`, string(th[1].Theme.Sections["background"]))
+	}
+}
+
+func TestUpdateAccountSigningThemes(t *testing.T) {
+	h, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	apiErr := c.UpdateAccountSigningThemes(ossign.SigningThemes{
+		{Name: "zebra", Theme: ossign.SigningTheme{Primary: "#000000"}},
+		{Name: "alpha", Theme: ossign.SigningTheme{Primary: "#FFFFFF"}},
+	})
+
+	assert.Nil(t, apiErr)
+	assert.Equal(t, `{"zebra":{"color":{"primary":"#000000","success":"","warning":"","error":"","info":"","signatureButton":"","optionalSignatureButton":""}},`+
+		`"alpha":{"color":{"primary":"#FFFFFF","success":"","warning":"","error":"","info":"","signatureButton":"","optionalSignatureButton":""}}}`, string(h.Latest().Body))
+}
+
+func TestUpdateAccountSigningThemesSections(t *testing.T) {
+	h, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	th, apiErr := c.GetAccountSigningThemes()
+	assert.Nil(t, apiErr)
+
+	// The sections that are not known by the client are sent back untouched
+	apiErr = c.UpdateAccountSigningThemes(th)
+	assert.Nil(t, apiErr)
+
+	var b map[string]map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(h.Latest().Body, &b))
+
+	assert.Equal(t, 3, len(b["default"]))
+	assert.JSONEq(t, `{"family": "Roboto", "size": 14}`, string(b["default"]["font"]))
+	assert.JSONEq(t, `{"color": "#FAFAFA"}`, string(b["default"]["background"]))
+	assert.JSONEq(t, `{"primary": "#1565C0", "success": "#2E7D32", "warning": "#F9A825", "error": "#C62828", "info": "#1565C0", "signatureButton": "#1565C0", "optionalSignatureButton": "#90CAF9"}`, string(b["default"]["color"]))
+	assert.Equal(t, 1, len(b["spring"]))
+}
+
+func TestSigningThemeEqual(t *testing.T) {
+	l := ossign.SigningTheme{
+		Primary:  "#abcdef",
+		Sections: map[string]json.RawMessage{"font": json.RawMessage(`{"family": "Roboto", "size": 14}`)},
+	}
+	r := ossign.SigningTheme{
+		Primary:  "#ABCDEF",
+		Sections: map[string]json.RawMessage{"font": json.RawMessage(`{"size":14,"family":"Roboto"}`)},
+	}
+
+	assert.True(t, l.Equal(r))
+
+	r.Sections["font"] = json.RawMessage(`{"size":16,"family":"Roboto"}`)
+	assert.False(t, l.Equal(r))
+
+	r.Sections = nil
+	assert.False(t, l.Equal(r))
+
+	l.Sections = nil
+	assert.True(t, l.Equal(r))
+}
+
+func TestSigningThemesJson(t *testing.T) {
+	th := ossign.SigningThemes{
+		{Name: "zebra", Theme: ossign.SigningTheme{Primary: "#000000", Sections: map[string]json.RawMessage{"font": json.RawMessage(`{"family":"Roboto"}`)}}},
+		{Name: "alpha", Theme: ossign.SigningTheme{Primary: "#FFFFFF"}},
+	}
+
+	b, err := json.Marshal(th)
+	assert.NoError(t, err)
+
+	var r ossign.SigningThemes
+	assert.NoError(t, json.Unmarshal(b, &r))
+	assert.Equal(t, th, r)
+
+	// Earlier versions of the provider marshalled the themes as a map
+	b, err = json.Marshal(map[string]ossign.SigningTheme{"default": {Primary: "#000000"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, json.Unmarshal(b, &r))
+	assert.Equal(t, ossign.SigningThemes{{Name: "default", Theme: ossign.SigningTheme{Primary: "#000000"}}}, r)
+
+	assert.NoError(t, json.Unmarshal([]byte("{}"), &r))
+	assert.Equal(t, ossign.SigningThemes{}, r)
+
+	assert.Error(t, json.Unmarshal([]byte("[]"), &r))
+}
+
+func TestCreateSender(t *testing.T) {
+	h, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	s, apiErr := c.CreateSender(ossign.Sender{
+		Email:     "jane.doe@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Type:      ossign.SenderTypeRegular,
+	})
+
+	assert.Nil(t, apiErr)
+	assert.Equal(t, "sender-id", s.Id)
+	assert.Equal(t, ossign.SenderStatusInvited, s.Status)
+
+	req := h.Latest()
+	assert.Equal(t, "POST", req.Request.Method)
+	assert.JSONEq(t, `{"email":"jane.doe@example.com","firstName":"Jane","lastName":"Doe","company":"","title":"","phone":"","type":"REGULAR"}`, string(req.Body))
+}
+
+func TestGetSender(t *testing.T) {
+	_, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	s, apiErr := c.GetSender("sender-id")
+
+	assert.Nil(t, apiErr)
+	assert.Equal(t, "jane.doe@example.com", s.Email)
+	assert.Equal(t, "Jane", s.FirstName)
+	assert.Equal(t, "Doe", s.LastName)
+	assert.Equal(t, "fr", s.Language)
+	assert.Equal(t, ossign.SenderTypeManager, s.Type)
+	assert.Equal(t, ossign.SenderStatusActive, s.Status)
+
+	_, apiErr = c.GetSender("deleted-sender-id")
+
+	assert.NotNil(t, apiErr)
+	assert.True(t, apiErr.IsNotFound())
+}
+
+func TestUpdateSender(t *testing.T) {
+	h, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	apiErr := c.UpdateSender("sender-id", ossign.Sender{
+		Id:        "sender-id",
+		Email:     "jane.doe@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Status:    ossign.SenderStatusLocked,
+	})
+
+	assert.Nil(t, apiErr)
+
+	// The read-only attributes are not sent
+	req := h.Latest()
+	assert.Equal(t, "POST", req.Request.Method)
+	assert.Equal(t, "/api/account/senders/sender-id", req.Request.URL.Path)
+	assert.JSONEq(t, `{"firstName":"Jane","lastName":"Doe","company":"","title":"","phone":"","status":"LOCKED"}`, string(req.Body))
+}
+
+func TestDeleteSender(t *testing.T) {
+	h, ts := setupTestServer(&testServerConfig{
+		AccessToken:       uuid.NewString(),
+		TokenExpiryOffset: 5,
+	})
+	defer ts.Close()
+
+	url, err := url.Parse(ts.URL)
+
+	if err != nil {
+		panic(err)
+	}
+
+	c := ossign.NewClient(ossign.ApiClientConfig{
+		BaseUrl:      url,
+		ClientId:     uuid.NewString(),
+		ClientSecret: uuid.NewString(),
+		UserAgent:    uuid.NewString(),
+	})
+
+	assert.Nil(t, c.DeleteSender("sender-id"))
+	assert.Equal(t, "DELETE", h.Latest().Request.Method)
+
+	apiErr := c.DeleteSender("deleted-sender-id")
+
+	assert.NotNil(t, apiErr)
+	assert.True(t, apiErr.IsNotFound())
+}
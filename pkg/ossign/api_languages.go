@@ -0,0 +1,40 @@
+package ossign
+
+// Language is a language of the Signing Ceremony, identified by its code.
+type Language string
+
+const (
+	LanguageEnglish            Language = "en"
+	LanguageFrench             Language = "fr"
+	LanguageItalian            Language = "it"
+	LanguageRussian            Language = "ru"
+	LanguageSpanish            Language = "es"
+	LanguagePortuguese         Language = "pt"
+	LanguageGerman             Language = "de"
+	LanguageDutch              Language = "nl"
+	LanguageDanish             Language = "da"
+	LanguageGreek              Language = "el"
+	LanguageChineseSimplified  Language = "zh-CN"
+	LanguageChineseTraditional Language = "zh-TW"
+	LanguageJapanese           Language = "ja"
+	LanguageKorean             Language = "ko"
+)
+
+// Languages are the languages supported by OneSpan Sign at the time of writing. The API doesn't document a way
+// to retrieve the languages supported by OneSpan Sign or enabled for an account.
+var Languages = []Language{
+	LanguageEnglish,
+	LanguageFrench,
+	LanguageItalian,
+	LanguageRussian,
+	LanguageSpanish,
+	LanguagePortuguese,
+	LanguageGerman,
+	LanguageDutch,
+	LanguageDanish,
+	LanguageGreek,
+	LanguageChineseSimplified,
+	LanguageChineseTraditional,
+	LanguageJapanese,
+	LanguageKorean,
+}